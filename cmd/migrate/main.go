@@ -1,81 +1,166 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
-	"github.com/joho/godotenv"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	"github.com/wonjinsin/go-boilerplate/internal/config"
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/database"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres"
+	"github.com/wonjinsin/go-boilerplate/internal/usecase"
+	"github.com/wonjinsin/go-boilerplate/migrations"
+	pkgErrors "github.com/wonjinsin/go-boilerplate/pkg/errors"
+	"github.com/wonjinsin/go-boilerplate/pkg/utils"
 )
 
+// migrationsDir is where create and diff write new migrations, relative to the repository root.
+const migrationsDir = "migrations"
+
+// adminPasswordEnv holds the password of the user created by the admin command.
+const adminPasswordEnv = "ADMIN_PASSWORD"
+
+// migrationNamePattern restricts names of created migrations.
+var migrationNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
 func main() {
 	// Set timezone to UTC for the entire program.
 	time.Local = time.UTC
 
-	// Load .env.local.
-	if err := godotenv.Load(".env.local"); err != nil {
-		log.Printf("Warning: .env.local not found: %v", err)
+	// Parse command and its arguments; flags after them override database settings.
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
 	}
+	command := os.Args[1]
+	cmdArgs, flagArgs := splitArgs(os.Args[2:])
+	dryRun, flagArgs := extractBoolFlag(flagArgs, "dry-run")
+	write, flagArgs := extractBoolFlag(flagArgs, "write")
+	devURL, flagArgs := extractStringFlag(flagArgs, "dev-url")
 
-	// Build DATABASE_URL from individual components.
-	dbHost := os.Getenv("DB_HOST")
-	dbPort := os.Getenv("DB_PORT")
-	dbUser := os.Getenv("DB_USER")
-	dbPassword := os.Getenv("DB_PASSWORD")
-	dbName := os.Getenv("DB_NAME")
-	dbSSLMode := os.Getenv("DB_SSLMODE")
-
-	if dbHost == "" || dbPort == "" || dbUser == "" || dbPassword == "" || dbName == "" {
-		log.Fatal(
-			"Required database environment variables are not set (DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME)",
-		)
+	// create only writes files and needs no database.
+	if command == "create" {
+		if len(cmdArgs) != 1 || !migrationNamePattern.MatchString(cmdArgs[0]) {
+			log.Fatal("create requires a NAME of lowercase letters, digits and underscores")
+		}
+		if err := createMigration(cmdArgs[0]); err != nil {
+			log.Fatalf("Failed to create migration: %v", err)
+		}
+		return
 	}
 
-	if dbSSLMode == "" {
-		dbSSLMode = "disable"
+	// diff migrates a disposable database and never touches the configured one.
+	if command == "diff" {
+		name := "schema_changes"
+		if len(cmdArgs) > 0 {
+			name = cmdArgs[0]
+		}
+		if len(cmdArgs) > 1 || !migrationNamePattern.MatchString(name) {
+			log.Fatal("diff takes an optional NAME of lowercase letters, digits and underscores")
+		}
+		if devURL == "" {
+			log.Fatal("diff requires -dev-url of a disposable database, where it applies every migration")
+		}
+		drift, err := diffSchema(devURL, name, write)
+		if err != nil {
+			log.Fatalf("Diff failed: %v", err)
+		}
+		if drift && !write {
+			os.Exit(1)
+		}
+		return
 	}
 
-	databaseURL := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s&timezone=UTC",
-		dbUser, dbPassword, dbHost, dbPort, dbName, dbSSLMode)
+	// Load database configuration shared with the server.
+	cfg, err := config.LoadDatabase(flagArgs)
+	if err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
+	}
 
 	// Create migration instance.
-	m, err := migrate.New(
-		"file://migrations",
-		databaseURL,
-	)
+	db, err := database.OpenMigrationDB(cfg)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	m, err := database.NewMigrator(db)
 	if err != nil {
 		log.Fatalf("Failed to create migrate instance: %v", err)
 	}
 	defer m.Close()
 
-	// Parse command from arguments.
-	if len(os.Args) < 2 {
-		printUsage()
-		os.Exit(1)
-	}
-
-	command := os.Args[1]
-
 	switch command {
 	case "up":
-		if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
-			log.Fatalf("Migration up failed: %v", err)
-		}
-		log.Println("Migration up completed successfully")
+		run(m, dryRun, "up", func(current int, versions []uint) []step {
+			return planUp(current, versions, len(versions))
+		}, m.Up)
 
 	case "down":
-		if err := m.Down(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
-			log.Fatalf("Migration down failed: %v", err)
+		run(m, dryRun, "down", func(current int, versions []uint) []step {
+			return planDown(current, versions, 1)
+		}, func() error { return m.Steps(-1) })
+
+	case "steps":
+		n := intArg(cmdArgs, "steps requires N (negative to roll back)")
+		run(m, dryRun, "steps", func(current int, versions []uint) []step {
+			if n < 0 {
+				return planDown(current, versions, -n)
+			}
+			return planUp(current, versions, n)
+		}, func() error { return m.Steps(n) })
+
+	case "goto":
+		target := intArg(cmdArgs, "goto requires a version V")
+		if target < 0 {
+			log.Fatal("goto requires a non-negative version")
+		}
+		run(m, dryRun, "goto", func(current int, versions []uint) []step {
+			return planGoto(current, versions, target)
+		}, func() error { return m.Migrate(uint(target)) })
+
+	case "force":
+		// Force only rewrites the recorded version, for recovery from a dirty state.
+		version := intArg(cmdArgs, "force requires a version V (-1 for none)")
+		if dryRun {
+			fmt.Printf("Would force version to %d\n", version)
+			return
+		}
+		if err := m.Force(version); err != nil {
+			log.Fatalf("Force failed: %v", err)
+		}
+		log.Printf("Forced version to %d", version)
+
+	case "admin":
+		// Admin bootstraps the first administrator, since managing users needs one.
+		if len(cmdArgs) < 1 || len(cmdArgs) > 2 {
+			log.Fatal("admin requires an EMAIL and takes an optional NAME")
+		}
+		name := "Admin"
+		if len(cmdArgs) == 2 {
+			name = cmdArgs[1]
+		}
+		if err := grantAdmin(context.Background(), cfg, cmdArgs[0], name, os.Getenv(adminPasswordEnv)); err != nil {
+			log.Fatalf("Failed to grant admin: %v", err)
 		}
-		log.Println("Migration down completed successfully")
 
 	case "version":
 		version, dirty, err := m.Version()
+		if errors.Is(err, migrate.ErrNilVersion) {
+			fmt.Println("No migrations applied")
+			return
+		}
 		if err != nil {
 			log.Fatalf("Failed to get version: %v", err)
 		}
@@ -88,10 +173,329 @@ func main() {
 	}
 }
 
+// step is one migration applied in one direction.
+type step struct {
+	version uint
+	up      bool
+}
+
+// run lists the steps of plan in dry-run mode, and otherwise applies the migration.
+func run(
+	m *migrate.Migrate,
+	dryRun bool,
+	name string,
+	plan func(current int, versions []uint) []step,
+	apply func() error,
+) {
+	if dryRun {
+		if err := printPlan(m, plan); err != nil {
+			log.Fatalf("Failed to plan %s: %v", name, err)
+		}
+		return
+	}
+
+	if err := apply(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		log.Fatalf("Migration %s failed: %v", name, err)
+	}
+	log.Printf("Migration %s completed successfully", name)
+}
+
+// printPlan prints the migrations plan would run from the current version.
+func printPlan(m *migrate.Migrate, plan func(current int, versions []uint) []step) error {
+	current := -1
+	version, dirty, err := m.Version()
+	switch {
+	case errors.Is(err, migrate.ErrNilVersion):
+	case err != nil:
+		return err
+	case dirty:
+		return fmt.Errorf("version %d is dirty; fix the schema and use force first", version)
+	default:
+		current = int(version)
+	}
+
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	versions, err := sourceVersions(src)
+	if err != nil {
+		return err
+	}
+
+	steps := plan(current, versions)
+	if len(steps) == 0 {
+		fmt.Println("No migrations to run")
+		return nil
+	}
+	for _, s := range steps {
+		read, direction := src.ReadUp, "up"
+		if !s.up {
+			read, direction = src.ReadDown, "down"
+		}
+		r, identifier, err := read(s.version)
+		if err != nil {
+			return fmt.Errorf("migration %d has no %s file: %w", s.version, direction, err)
+		}
+		_ = r.Close()
+		fmt.Printf("%-4s %d %s\n", direction, s.version, identifier)
+	}
+	return nil
+}
+
+// sourceVersions returns all migration versions in ascending order.
+func sourceVersions(src source.Driver) ([]uint, error) {
+	var versions []uint
+	v, err := src.First()
+	for err == nil {
+		versions = append(versions, v)
+		v, err = src.Next(v)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// planUp returns up to n migrations above current, in ascending order.
+func planUp(current int, versions []uint, n int) []step {
+	var steps []step
+	for _, v := range versions {
+		if int(v) > current && len(steps) < n {
+			steps = append(steps, step{version: v, up: true})
+		}
+	}
+	return steps
+}
+
+// planDown returns up to n applied migrations, newest first.
+func planDown(current int, versions []uint, n int) []step {
+	var steps []step
+	for _, v := range slices.Backward(versions) {
+		if int(v) <= current && len(steps) < n {
+			steps = append(steps, step{version: v, up: false})
+		}
+	}
+	return steps
+}
+
+// planGoto returns the migrations between current and target.
+func planGoto(current int, versions []uint, target int) []step {
+	var steps []step
+	if target >= current {
+		for _, s := range planUp(current, versions, len(versions)) {
+			if int(s.version) <= target {
+				steps = append(steps, s)
+			}
+		}
+		return steps
+	}
+	for _, s := range planDown(current, versions, len(versions)) {
+		if int(s.version) > target {
+			steps = append(steps, s)
+		}
+	}
+	return steps
+}
+
+// createMigration writes empty up and down files with the next sequential version.
+func createMigration(name string) error {
+	return writeMigration(name, []database.MigrationFile{
+		{Name: name + ".up.sql", Content: []byte("-- " + name + " (up)\n")},
+		{Name: name + ".down.sql", Content: []byte("-- " + name + " (down)\n")},
+	})
+}
+
+// diffSchema applies every migration to the disposable database at devURL, then prints
+// the statements that bring it in line with the ent schema and reports whether there are
+// any. With write, they are saved as the next migration instead.
+func diffSchema(devURL, name string, write bool) (bool, error) {
+	db, err := database.OpenScratchDB(devURL)
+	if err != nil {
+		return false, err
+	}
+	m, err := database.NewMigrator(db)
+	if err != nil {
+		_ = db.Close()
+		return false, err
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return false, fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	files, err := database.DiffSchema(context.Background(), db, name)
+	if err != nil {
+		return false, err
+	}
+	if len(files) == 0 {
+		fmt.Println("No schema drift: migrations match the ent schema")
+		return false, nil
+	}
+	if write {
+		return true, writeMigration(name, files)
+	}
+
+	for _, f := range files {
+		fmt.Printf("-- %s\n%s\n", f.Name, f.Content)
+	}
+	fmt.Println("Schema drift detected: run diff with -write to generate the migration")
+	return true, nil
+}
+
+// writeMigration writes files, named <name>.up.sql and <name>.down.sql, with the next
+// sequential version. Existing files are never overwritten.
+func writeMigration(name string, files []database.MigrationFile) error {
+	version, err := nextVersion()
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		path := filepath.Join(migrationsDir, fmt.Sprintf("%06d_%s", version, file.Name))
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(file.Content)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+		log.Printf("Created %s", path)
+	}
+	return nil
+}
+
+// nextVersion returns the version after the highest one in migrationsDir.
+func nextVersion() (uint64, error) {
+	entries, err := os.ReadDir(migrationsDir)
+	if err != nil {
+		return 0, err
+	}
+
+	var last uint64
+	for _, e := range entries {
+		prefix, _, ok := strings.Cut(e.Name(), "_")
+		if !ok {
+			continue
+		}
+		if v, err := strconv.ParseUint(prefix, 10, 64); err == nil && v > last {
+			last = v
+		}
+	}
+	return last + 1, nil
+}
+
+// grantAdmin gives the admin role to the live user with email, creating it with name and
+// password first if it does not exist. It goes through the application's repositories in
+// one transaction, so the created user gets its defaults and a create audit event.
+func grantAdmin(ctx context.Context, cfg *config.DatabaseConfig, email, name, password string) error {
+	client, _, err := database.NewEntClient(cfg)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	isolation, err := postgres.ParseIsolationLevel(cfg.TxIsolation)
+	if err != nil {
+		return err
+	}
+	bootstrapper := usecase.NewAdminBootstrapper(
+		postgres.NewTxManager(client, postgres.TxOptions{Isolation: isolation, MaxRetries: cfg.TxMaxRetries}),
+		postgres.NewUserRepository(client, cfg.QueryTimeout),
+		postgres.NewRoleRepository(client, cfg.QueryTimeout),
+		// bcrypt's default cost; logins accept any cost.
+		utils.NewBcryptHasher(0),
+	)
+
+	u, created, err := bootstrapper.BootstrapAdmin(ctx, email, name, password)
+	if err != nil {
+		if password == "" && pkgErrors.HasCode(err, constants.InvalidParameter) {
+			return fmt.Errorf("%w: set %s to create the user", err, adminPasswordEnv)
+		}
+		return err
+	}
+	if created {
+		log.Printf("Created user %s", u.Email)
+	}
+	log.Printf("Granted admin to user %s (id %d)", u.Email, u.ID)
+	return nil
+}
+
+// splitArgs separates leading positional command arguments from the flags after them.
+func splitArgs(args []string) (cmdArgs, flagArgs []string) {
+	i := 0
+	for i < len(args) && (!strings.HasPrefix(args[i], "-") || isNumber(args[i])) {
+		i++
+	}
+	return args[:i], args[i:]
+}
+
+// extractBoolFlag removes the boolean flag -name (or --name) from args and reports
+// whether it was present.
+func extractBoolFlag(args []string, name string) (bool, []string) {
+	isFlag := func(a string) bool { return a == "-"+name || a == "--"+name }
+	return slices.ContainsFunc(args, isFlag), slices.DeleteFunc(slices.Clone(args), isFlag)
+}
+
+// extractStringFlag removes the flag -name value (or -name=value, with one or two dashes)
+// from args and returns its value, empty when absent.
+func extractStringFlag(args []string, name string) (string, []string) {
+	rest := make([]string, 0, len(args))
+	var value string
+	for i := 0; i < len(args); i++ {
+		flag := strings.TrimPrefix(strings.TrimPrefix(args[i], "-"), "-")
+		if flag == args[i] {
+			rest = append(rest, args[i])
+			continue
+		}
+		switch {
+		case flag == name && i+1 < len(args):
+			value = args[i+1]
+			i++
+		case strings.HasPrefix(flag, name+"="):
+			value = strings.TrimPrefix(flag, name+"=")
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+	return value, rest
+}
+
+// intArg parses the single integer argument of a command or exits with usage.
+func intArg(args []string, usage string) int {
+	if len(args) != 1 || !isNumber(args[0]) {
+		log.Fatal(usage)
+	}
+	n, _ := strconv.Atoi(args[0])
+	return n
+}
+
+// isNumber reports whether s is an integer, so negative step counts are not taken for flags.
+func isNumber(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
 func printUsage() {
-	fmt.Println("Usage: go run cmd/migrate/main.go [command]")
+	fmt.Println("Usage: go run cmd/migrate/main.go [command] [args] [-dry-run] [-write] [-dev-url url] [-config file] [-database.<key> value ...]")
 	fmt.Println("\nCommands:")
-	fmt.Println("  up       - Run all pending migrations")
-	fmt.Println("  down     - Rollback last migration")
-	fmt.Println("  version  - Show current migration version")
+	fmt.Println("  up                 - Run all pending migrations")
+	fmt.Println("  down               - Rollback last migration")
+	fmt.Println("  steps N            - Run N migrations up, or -N down")
+	fmt.Println("  goto V             - Migrate up or down to version V")
+	fmt.Println("  force V            - Set version V without running migrations (recovers a dirty state)")
+	fmt.Println("  version            - Show current migration version")
+	fmt.Println("  admin EMAIL [NAME] - Grant the admin role, creating the user with $" + adminPasswordEnv + " if missing")
+	fmt.Println("  create NAME        - Create empty up/down migration files in migrations/")
+	fmt.Println("  diff [NAME]        - Apply all migrations to -dev-url, then compare it with the ent schema")
+	fmt.Println("\n-dry-run lists the migrations up, down, steps and goto would run without applying them.")
+	fmt.Println("diff exits with status 1 on drift; -write saves the difference as the next migration.")
+	fmt.Println("diff never touches the configured database; -dev-url must name a disposable one.")
 }
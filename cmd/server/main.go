@@ -2,23 +2,37 @@ package main
 
 import (
 	"context"
+	"crypto"
 	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+
 	"github.com/wonjinsin/go-boilerplate/internal/config"
 	"github.com/wonjinsin/go-boilerplate/internal/database"
 	httpHandler "github.com/wonjinsin/go-boilerplate/internal/handler/http"
+	custommiddleware "github.com/wonjinsin/go-boilerplate/internal/handler/http/middleware"
 	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres"
 	"github.com/wonjinsin/go-boilerplate/internal/usecase"
+	"github.com/wonjinsin/go-boilerplate/pkg/health"
+	"github.com/wonjinsin/go-boilerplate/pkg/jwtauth"
 	"github.com/wonjinsin/go-boilerplate/pkg/logger"
+	"github.com/wonjinsin/go-boilerplate/pkg/ratelimit"
+	"github.com/wonjinsin/go-boilerplate/pkg/tracing"
+	"github.com/wonjinsin/go-boilerplate/pkg/utils"
 )
 
+// serviceName identifies this service in traces unless OTEL_SERVICE_NAME overrides it.
+const serviceName = "go-boilerplate"
+
 func main() {
 	// Print ASCII art banner.
 	printBanner()
@@ -27,13 +41,38 @@ func main() {
 	time.Local = time.UTC
 
 	// Load configuration.
-	cfg := config.Load()
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
+	}
+	log.Printf("Configuration loaded: %s", cfg)
 
 	// Initialize logger.
-	logger.Initialize(cfg.Env)
+	logger.Initialize(cfg.Server.Env)
+
+	// Initialize tracing.
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		ServiceName: serviceName,
+		Exporter:    cfg.Tracing.Exporter,
+		File:        cfg.Tracing.File,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		log.Fatalf("failed to initialize tracing: %v", err)
+	}
+
+	// Outbound calls made with a request context forward its TrID.
+	http.DefaultTransport = utils.NewTrIDTransport(http.DefaultTransport, cfg.Server.RequestIDHeader)
+
+	// Apply pending migrations; concurrent instances wait on the migration lock.
+	if cfg.Database.MigrateOnStart {
+		if err := database.MigrateUp(&cfg.Database); err != nil {
+			log.Fatalf("failed to migrate database: %v", err)
+		}
+	}
 
 	// Initialize database client.
-	entClient, err := database.NewEntClient(cfg)
+	entClient, pools, err := database.NewEntClient(&cfg.Database)
 	if err != nil {
 		log.Fatalf("failed to initialize database: %v", err)
 	}
@@ -44,45 +83,225 @@ func main() {
 	}()
 
 	// Initialize repositories.
-	userRepo := postgres.NewUserRepository(entClient)
+	isolation, err := postgres.ParseIsolationLevel(cfg.Database.TxIsolation)
+	if err != nil {
+		log.Fatalf("invalid DB_TX_ISOLATION: %v", err)
+	}
+	txManager := postgres.NewTxManager(entClient, postgres.TxOptions{
+		Isolation:  isolation,
+		MaxRetries: cfg.Database.TxMaxRetries,
+	})
+	userRepo := postgres.NewUserRepository(entClient, cfg.Database.QueryTimeout)
+	refreshTokenRepo := postgres.NewRefreshTokenRepository(entClient, cfg.Database.QueryTimeout)
+	roleRepo := postgres.NewRoleRepository(entClient, cfg.Database.QueryTimeout)
+	apiKeyRepo := postgres.NewAPIKeyRepository(entClient, cfg.Database.QueryTimeout)
+	idempotencyRepo := postgres.NewIdempotencyRepository(entClient, cfg.Database.QueryTimeout)
+	rateLimitRepo := postgres.NewRateLimitRepository(entClient, cfg.Database.QueryTimeout)
+	auditRepo := postgres.NewAuditRepository(entClient, cfg.Database.QueryTimeout)
+
+	// Initialize authentication.
+	verifier, err := newTokenVerifier(&cfg.Auth)
+	if err != nil {
+		log.Fatalf("failed to initialize token verifier: %v", err)
+	}
+	signer, err := newTokenSigner(&cfg.Auth)
+	if err != nil {
+		log.Fatalf("failed to initialize token signer: %v", err)
+	}
+	hasher := utils.NewBcryptHasher(cfg.Auth.PasswordHashCost)
 
 	// Wiring (Composition Root).
-	userSvc := usecase.NewUserService(userRepo)
+	authz := usecase.NewAuthorizer(roleRepo)
+	userSvc := usecase.NewTracedUserService(usecase.NewUserService(txManager, userRepo, hasher, authz))
+	authSvc := usecase.NewAuthService(
+		txManager, userRepo, refreshTokenRepo, signer, hasher, cfg.Auth.RefreshTokenTTL,
+	)
+	apiKeySvc := usecase.NewAPIKeyService(apiKeyRepo, userRepo, authz)
+	auditSvc := usecase.NewAuditService(auditRepo, authz)
+	idempotencySvc := usecase.NewIdempotencyService(
+		idempotencyRepo, cfg.Idempotency.TTL, cfg.Idempotency.LockTimeout,
+	)
+	var limiter ratelimit.Limiter = ratelimit.NewTokenBucket()
+	if cfg.RateLimit.Store == ratelimit.StorePostgres {
+		limiter = ratelimit.NewSlidingWindow(rateLimitRepo)
+	}
+
+	// Metrics, served on the admin port.
+	registry := newMetricsRegistry(pools, cfg.Database.Name)
+	httpMetrics := custommiddleware.NewHTTPMetrics(registry)
+
+	// Readiness checks.
+	readiness := health.NewReadiness(cfg.Health.CheckTimeout, cfg.Health.CacheTTL)
+	readiness.Register("database", database.PingCheck(pools.Primary))
+	readiness.Register("migrations", database.MigrationCheck(pools.Primary))
+	if pools.Replica != nil {
+		readiness.Register("database_replica", database.PingCheck(pools.Replica))
+	}
 
 	// Create chi router.
-	router := httpHandler.NewRouter(userSvc)
+	router := httpHandler.NewRouter(
+		cfg, userSvc, authSvc, apiKeySvc, auditSvc, verifier, authz, httpMetrics, readiness,
+		idempotencySvc, limiter,
+	)
+
+	// Request contexts derive from baseCtx so a forced shutdown cancels in-flight queries.
+	baseCtx, cancelBase := context.WithCancel(context.Background())
+	defer cancelBase()
 
 	srv := &http.Server{
-		Addr:              fmt.Sprintf(":%s", cfg.Port),
+		Addr:              fmt.Sprintf(":%d", cfg.Server.Port),
 		Handler:           router,
+		BaseContext:       func(net.Listener) context.Context { return baseCtx },
 		ReadHeaderTimeout: 5 * time.Second,
 		ReadTimeout:       10 * time.Second,
 		WriteTimeout:      10 * time.Second,
 		IdleTimeout:       60 * time.Second,
 	}
 
+	adminSrv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Server.AdminPort),
+		Handler:           httpHandler.NewAdminRouter(registry),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      10 * time.Second,
+		IdleTimeout:       60 * time.Second,
+	}
+
+	go purgeExpired(baseCtx, "idempotency keys", cfg.Idempotency.PurgeInterval, idempotencySvc.PurgeExpired)
+	if cfg.RateLimit.Store == ratelimit.StorePostgres {
+		go purgeExpired(baseCtx, "rate limit counters", cfg.RateLimit.PurgeInterval,
+			func(ctx context.Context) (int, error) { return rateLimitRepo.DeleteExpired(ctx, time.Now()) })
+	}
+
 	go func() {
 		log.Printf("HTTP server starting on %s", srv.Addr)
 		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
 			log.Fatalf("server error: %v", err)
 		}
 	}()
+	go func() {
+		log.Printf("admin server starting on %s", adminSrv.Addr)
+		if err := adminSrv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("admin server error: %v", err)
+		}
+	}()
 
 	// Graceful shutdown.
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
 	<-stop
 
+	// Fail readiness first so load balancers stop routing new traffic before the server closes.
+	log.Println("draining...")
+	readiness.Drain()
+	time.Sleep(cfg.Server.ShutdownDrainDelay)
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	log.Println("shutting down...")
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Printf("graceful shutdown failed: %v", err)
+		cancelBase()
 		_ = srv.Close()
 	}
+	if err := adminSrv.Shutdown(ctx); err != nil {
+		log.Printf("admin server shutdown failed: %v", err)
+		_ = adminSrv.Close()
+	}
+	if err := shutdownTracing(ctx); err != nil {
+		log.Printf("tracing shutdown failed: %v", err)
+	}
 	log.Println("bye")
 }
 
+// purgeExpired runs purge every interval until ctx is done. what names the purged rows
+// in logs.
+func purgeExpired(ctx context.Context, what string, interval time.Duration, purge func(context.Context) (int, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := purge(ctx)
+			if err != nil {
+				log.Printf("failed to purge %s: %v", what, err)
+				continue
+			}
+			if n > 0 {
+				log.Printf("purged %d expired %s", n, what)
+			}
+		}
+	}
+}
+
+// newMetricsRegistry creates the registry served on /metrics with Go runtime, process
+// and database connection pool collectors.
+func newMetricsRegistry(pools *database.DB, dbName string) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewDBStatsCollector(pools.Primary, dbName),
+	)
+	if pools.Replica != nil {
+		registry.MustRegister(collectors.NewDBStatsCollector(pools.Replica, dbName+"_replica"))
+	}
+	return registry
+}
+
+// newTokenVerifier builds a JWT verifier from the configured key sources.
+func newTokenVerifier(cfg *config.AuthConfig) (*jwtauth.Verifier, error) {
+	var sources jwtauth.KeySources
+
+	if cfg.JWTJWKSFile != "" {
+		jwks, err := jwtauth.NewJWKSFile(cfg.JWTJWKSFile, cfg.JWTJWKSRefresh)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, jwks)
+	}
+
+	var publicKey crypto.PublicKey
+	if cfg.JWTPublicKeyFile != "" {
+		key, err := jwtauth.LoadPublicKeyPEM(cfg.JWTPublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		publicKey = key
+	}
+	if publicKey != nil || cfg.JWTHMACSecret != "" {
+		sources = append(sources, jwtauth.NewStaticKeySource([]byte(cfg.JWTHMACSecret), publicKey))
+	}
+
+	return jwtauth.NewVerifier(sources, jwtauth.VerifierConfig{
+		Issuer:    cfg.JWTIssuer,
+		Audience:  cfg.JWTAudience,
+		ClockSkew: cfg.JWTClockSkew,
+	}), nil
+}
+
+// newTokenSigner builds the access token signer, preferring an asymmetric private key.
+func newTokenSigner(cfg *config.AuthConfig) (*jwtauth.Signer, error) {
+	signerCfg := jwtauth.SignerConfig{
+		Issuer:   cfg.JWTIssuer,
+		Audience: cfg.JWTAudience,
+		KeyID:    cfg.JWTSigningKeyID,
+		TTL:      cfg.AccessTokenTTL,
+	}
+
+	if cfg.JWTPrivateKeyFile != "" {
+		key, err := jwtauth.LoadPrivateKeyPEM(cfg.JWTPrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		return jwtauth.NewPrivateKeySigner(key, signerCfg)
+	}
+	return jwtauth.NewHMACSigner([]byte(cfg.JWTHMACSecret), signerCfg), nil
+}
+
 func printBanner() {
 	// Read banner from file.
 	bannerPath := "internal/config/banner.asc"
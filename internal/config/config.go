@@ -1,68 +1,341 @@
 package config
 
 import (
+	"errors"
 	"fmt"
-	"log"
-	"os"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
 
-	"github.com/joho/godotenv"
+	"github.com/wonjinsin/go-boilerplate/pkg/constants"
+	"github.com/wonjinsin/go-boilerplate/pkg/ratelimit"
+	"github.com/wonjinsin/go-boilerplate/pkg/tracing"
 )
 
 // Config holds all application configuration.
+//
+// Every field is read from, in increasing priority: its default, the config file
+// (YAML or TOML, selected with -config or CONFIG_FILE), the environment variable in its
+// env tag (.env.local is loaded into the environment first), and the command-line flag
+// named after its file key (e.g. -database.query_timeout).
 type Config struct {
-	Port       string
-	Env        string
-	DBHost     string
-	DBPort     string
-	DBUser     string
-	DBPassword string
-	DBName     string
-	DBSSLMode  string
+	Server      ServerConfig      `config:"server"`
+	Database    DatabaseConfig    `config:"database"`
+	Auth        AuthConfig        `config:"auth"`
+	Pagination  PaginationConfig  `config:"pagination"`
+	Tracing     TracingConfig     `config:"tracing"`
+	Health      HealthConfig      `config:"health"`
+	Idempotency IdempotencyConfig `config:"idempotency"`
+	RateLimit   RateLimitConfig   `config:"rate_limit"`
 }
 
-// Load reads configuration from .env.local file and environment variables.
-// Environment variables take priority over file values.
-func Load() *Config {
-	// Try to load .env.local file (ignore error if file doesn't exist).
-	_ = godotenv.Load(".env.local")
+// ServerConfig configures the HTTP servers.
+type ServerConfig struct {
+	Port      int    `config:"port" env:"PORT" required:"true"`
+	AdminPort int    `config:"admin_port" env:"ADMIN_PORT" default:"9090"` // Operational endpoints (/metrics), kept off the public port.
+	Env       string `config:"env" env:"ENV" required:"true"`
 
-	cfg := &Config{
-		Port:       mustGetEnv("PORT"),
-		Env:        mustGetEnv("ENV"),
-		DBHost:     mustGetEnv("DB_HOST"),
-		DBPort:     mustGetEnv("DB_PORT"),
-		DBUser:     mustGetEnv("DB_USER"),
-		DBPassword: mustGetEnv("DB_PASSWORD"),
-		DBName:     mustGetEnv("DB_NAME"),
-		DBSSLMode:  getEnvOrDefault("DB_SSLMODE", "disable"),
+	// RequestIDHeader carries inbound transaction IDs and echoes them in responses.
+	RequestIDHeader string `config:"request_id_header" env:"REQUEST_ID_HEADER" default:"X-Request-ID"`
+	// ShutdownDrainDelay is how long /readyz fails on shutdown before the server stops.
+	ShutdownDrainDelay time.Duration `config:"shutdown_drain_delay" env:"SHUTDOWN_DRAIN_DELAY" default:"5s"`
+
+	CORSAllowedOrigins   []string `config:"cors_allowed_origins" env:"CORS_ALLOWED_ORIGINS" default:"*"`
+	CORSAllowCredentials bool     `config:"cors_allow_credentials" env:"CORS_ALLOW_CREDENTIALS"`
+
+	// MaxBodyBytes caps request bodies; larger JSON bodies are rejected with 413.
+	MaxBodyBytes int64 `config:"max_body_bytes" env:"MAX_BODY_BYTES" default:"1048576"`
+
+	// ErrorFormat renders errors as "standard" envelopes or RFC 7807 "problem" details.
+	// Requests accepting application/problem+json always get problem details.
+	ErrorFormat string `config:"error_format" env:"ERROR_FORMAT" default:"standard"`
+}
+
+// DatabaseConfig configures the PostgreSQL connection.
+type DatabaseConfig struct {
+	Host     string `config:"host" env:"DB_HOST" required:"true"`
+	Port     int    `config:"port" env:"DB_PORT" default:"5432"`
+	User     string `config:"user" env:"DB_USER" required:"true"`
+	Password string `config:"password" env:"DB_PASSWORD" required:"true" secret:"true"`
+	Name     string `config:"name" env:"DB_NAME" required:"true"`
+	SSLMode  string `config:"sslmode" env:"DB_SSLMODE" default:"disable"`
+
+	// QueryTimeout bounds each repository call; zero disables it.
+	QueryTimeout time.Duration `config:"query_timeout" env:"DB_QUERY_TIMEOUT" default:"5s"`
+	// TxIsolation is the isolation level of use-case transactions.
+	TxIsolation  string `config:"tx_isolation" env:"DB_TX_ISOLATION" default:"serializable"`
+	TxMaxRetries int    `config:"tx_max_retries" env:"DB_TX_MAX_RETRIES" default:"3"`
+
+	// Connection pool, applied to the primary and the replica alike. Zero means no limit.
+	MaxOpenConns    int           `config:"max_open_conns" env:"DB_MAX_OPEN_CONNS" default:"25"`
+	MaxIdleConns    int           `config:"max_idle_conns" env:"DB_MAX_IDLE_CONNS" default:"25"`
+	ConnMaxLifetime time.Duration `config:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME" default:"30m"`
+	ConnMaxIdleTime time.Duration `config:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME" default:"5m"`
+	// StatementTimeout is enforced by PostgreSQL on every statement; zero disables it.
+	StatementTimeout time.Duration `config:"statement_timeout" env:"DB_STATEMENT_TIMEOUT"`
+
+	// Startup connection attempts after the first, with backoff doubling from ConnectRetryBackoff.
+	ConnectRetries      int           `config:"connect_retries" env:"DB_CONNECT_RETRIES" default:"5"`
+	ConnectRetryBackoff time.Duration `config:"connect_retry_backoff" env:"DB_CONNECT_RETRY_BACKOFF" default:"1s"`
+
+	// MigrateOnStart applies pending embedded migrations before the server starts.
+	MigrateOnStart bool `config:"migrate_on_start" env:"DB_MIGRATE_ON_START"`
+
+	// ReplicaURL is an optional read replica connection string. User reads that tolerate
+	// replication lag are sent to it; every other statement uses the primary.
+	ReplicaURL string `config:"replica_url" env:"DB_REPLICA_URL" secret:"true"`
+}
+
+// AuthConfig configures JWT verification, token issuing and password hashing.
+type AuthConfig struct {
+	// JWT verification. At least one key source must be configured.
+	JWTIssuer        string        `config:"jwt_issuer" env:"JWT_ISSUER"`
+	JWTAudience      string        `config:"jwt_audience" env:"JWT_AUDIENCE"`
+	JWTClockSkew     time.Duration `config:"jwt_clock_skew" env:"JWT_CLOCK_SKEW" default:"30s"`
+	JWTHMACSecret    string        `config:"jwt_hmac_secret" env:"JWT_HMAC_SECRET" secret:"true"`
+	JWTPublicKeyFile string        `config:"jwt_public_key_file" env:"JWT_PUBLIC_KEY_FILE"`
+	JWTJWKSFile      string        `config:"jwt_jwks_file" env:"JWT_JWKS_FILE"`
+	JWTJWKSRefresh   time.Duration `config:"jwt_jwks_refresh" env:"JWT_JWKS_REFRESH" default:"1m"`
+
+	// Token issuing. Access tokens are signed with JWT_PRIVATE_KEY_FILE when set,
+	// otherwise with JWT_HMAC_SECRET.
+	JWTPrivateKeyFile string        `config:"jwt_private_key_file" env:"JWT_PRIVATE_KEY_FILE"`
+	JWTSigningKeyID   string        `config:"jwt_signing_key_id" env:"JWT_SIGNING_KEY_ID"`
+	AccessTokenTTL    time.Duration `config:"access_token_ttl" env:"ACCESS_TOKEN_TTL" default:"15m"`
+	RefreshTokenTTL   time.Duration `config:"refresh_token_ttl" env:"REFRESH_TOKEN_TTL" default:"720h"`
+	PasswordHashCost  int           `config:"password_hash_cost" env:"PASSWORD_HASH_COST" default:"12"`
+}
+
+// PaginationConfig configures list pagination.
+type PaginationConfig struct {
+	// CursorSecret signs opaque pagination cursors.
+	CursorSecret string `config:"cursor_secret" env:"CURSOR_SECRET" required:"true" secret:"true"`
+}
+
+// TracingConfig configures OpenTelemetry tracing. The OTLP exporter is configured
+// through the standard OTEL_EXPORTER_OTLP_* variables.
+type TracingConfig struct {
+	Exporter    string  `config:"exporter" env:"TRACING_EXPORTER" default:"none"`
+	File        string  `config:"file" env:"TRACING_FILE" default:"traces.json"`
+	SampleRatio float64 `config:"sample_ratio" env:"TRACING_SAMPLE_RATIO" default:"1"`
+}
+
+// HealthConfig configures readiness checks, which run with CheckTimeout and are
+// cached for CacheTTL.
+type HealthConfig struct {
+	CheckTimeout time.Duration `config:"check_timeout" env:"HEALTH_CHECK_TIMEOUT" default:"2s"`
+	CacheTTL     time.Duration `config:"cache_ttl" env:"HEALTH_CACHE_TTL" default:"1s"`
+}
+
+// IdempotencyConfig configures Idempotency-Key handling. Responses are replayed for TTL;
+// a running request holds its key for a LockTimeout lease, renewed while it runs, so a
+// key is taken over only when its request died.
+type IdempotencyConfig struct {
+	TTL           time.Duration `config:"ttl" env:"IDEMPOTENCY_TTL" default:"24h"`
+	LockTimeout   time.Duration `config:"lock_timeout" env:"IDEMPOTENCY_LOCK_TIMEOUT" default:"1m"`
+	PurgeInterval time.Duration `config:"purge_interval" env:"IDEMPOTENCY_PURGE_INTERVAL" default:"1h"`
+}
+
+// RateLimitConfig configures per-client rate limits. Requests per Window are allowed to
+// each API key or user on protected routes, IPRequests per IPWindow to each client IP on
+// protected routes before authentication, and AuthRequests per AuthWindow to each client
+// IP on /auth; zero requests disables a limit. The "memory" store limits each
+// instance separately, "postgres" shares limits across replicas.
+type RateLimitConfig struct {
+	Store         string        `config:"store" env:"RATE_LIMIT_STORE" default:"memory"`
+	Requests      int           `config:"requests" env:"RATE_LIMIT_REQUESTS" default:"100"`
+	Window        time.Duration `config:"window" env:"RATE_LIMIT_WINDOW" default:"1m"`
+	IPRequests    int           `config:"ip_requests" env:"RATE_LIMIT_IP_REQUESTS" default:"300"`
+	IPWindow      time.Duration `config:"ip_window" env:"RATE_LIMIT_IP_WINDOW" default:"1m"`
+	AuthRequests  int           `config:"auth_requests" env:"RATE_LIMIT_AUTH_REQUESTS" default:"10"`
+	AuthWindow    time.Duration `config:"auth_window" env:"RATE_LIMIT_AUTH_WINDOW" default:"1m"`
+	PurgeInterval time.Duration `config:"purge_interval" env:"RATE_LIMIT_PURGE_INTERVAL" default:"10m"`
+}
+
+// Load reads the full configuration from args (usually os.Args[1:]) and the environment
+// and validates it. All problems are reported together in the returned error.
+func Load(args []string) (*Config, error) {
+	cfg := &Config{}
+	if err := errors.Join(load(cfg, "", args), validateRequired(cfg), cfg.Validate()); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// LoadDatabase reads only the database section, for tools that do not run the server.
+func LoadDatabase(args []string) (*DatabaseConfig, error) {
+	cfg := &DatabaseConfig{}
+	if err := errors.Join(load(cfg, "database", args), validateRequired(cfg), cfg.Validate()); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate reports every invalid value that a field's tags cannot express.
+func (c *Config) Validate() error {
+	return errors.Join(
+		c.Server.Validate(),
+		c.Database.Validate(),
+		c.Auth.Validate(),
+		c.Tracing.Validate(),
+		c.Idempotency.Validate(),
+		c.RateLimit.Validate(),
+	)
+}
+
+// Validate reports every invalid server field.
+func (c *ServerConfig) Validate() error {
+	var errs []error
+	if err := validatePort("PORT", c.Port); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validatePort("ADMIN_PORT", c.AdminPort); err != nil {
+		errs = append(errs, err)
 	}
+	if c.Port != 0 && c.Port == c.AdminPort {
+		errs = append(errs, errors.New("ADMIN_PORT must differ from PORT"))
+	}
+	// Browsers reject credentialed responses with a wildcard origin.
+	if c.CORSAllowCredentials && slices.Contains(c.CORSAllowedOrigins, "*") {
+		errs = append(errs, errors.New("CORS_ALLOW_CREDENTIALS requires explicit CORS_ALLOWED_ORIGINS"))
+	}
+	if c.ShutdownDrainDelay < 0 {
+		errs = append(errs, errors.New("SHUTDOWN_DRAIN_DELAY must not be negative"))
+	}
+	if c.MaxBodyBytes <= 0 {
+		errs = append(errs, errors.New("MAX_BODY_BYTES must be positive"))
+	}
+	if c.ErrorFormat != constants.ErrorFormatStandard && c.ErrorFormat != constants.ErrorFormatProblem {
+		errs = append(errs, fmt.Errorf("ERROR_FORMAT %q must be one of standard or problem", c.ErrorFormat))
+	}
+	return errors.Join(errs...)
+}
+
+// Validate reports every invalid database field.
+func (c *DatabaseConfig) Validate() error {
+	var errs []error
+	if err := validatePort("DB_PORT", c.Port); err != nil {
+		errs = append(errs, err)
+	}
+	if c.QueryTimeout < 0 {
+		errs = append(errs, errors.New("DB_QUERY_TIMEOUT must not be negative"))
+	}
+	if c.TxMaxRetries < 0 {
+		errs = append(errs, errors.New("DB_TX_MAX_RETRIES must not be negative"))
+	}
+	if c.MaxOpenConns < 0 || c.MaxIdleConns < 0 {
+		errs = append(errs, errors.New("DB_MAX_OPEN_CONNS and DB_MAX_IDLE_CONNS must not be negative"))
+	}
+	if c.MaxOpenConns > 0 && c.MaxIdleConns > c.MaxOpenConns {
+		errs = append(errs, errors.New("DB_MAX_IDLE_CONNS must not exceed DB_MAX_OPEN_CONNS"))
+	}
+	if c.ConnMaxLifetime < 0 || c.ConnMaxIdleTime < 0 || c.StatementTimeout < 0 {
+		errs = append(errs, errors.New(
+			"DB_CONN_MAX_LIFETIME, DB_CONN_MAX_IDLE_TIME and DB_STATEMENT_TIMEOUT must not be negative",
+		))
+	}
+	if c.ConnectRetries < 0 || c.ConnectRetryBackoff < 0 {
+		errs = append(errs, errors.New("DB_CONNECT_RETRIES and DB_CONNECT_RETRY_BACKOFF must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// minHMACSecretLength is the shortest JWT_HMAC_SECRET accepted, the SHA-256 output size.
+const minHMACSecretLength = 32
+
+// Validate reports every invalid authentication field.
+func (c *AuthConfig) Validate() error {
+	var errs []error
+	if c.JWTHMACSecret == "" && c.JWTPublicKeyFile == "" && c.JWTJWKSFile == "" {
+		errs = append(errs, errors.New("one of JWT_HMAC_SECRET, JWT_PUBLIC_KEY_FILE or JWT_JWKS_FILE must be set"))
+	}
+	// A set secret enables HS256, whose key must not be shorter than the hash (RFC 7518).
+	if c.JWTHMACSecret != "" && len(c.JWTHMACSecret) < minHMACSecretLength {
+		errs = append(errs, fmt.Errorf("JWT_HMAC_SECRET must be at least %d bytes", minHMACSecretLength))
+	}
+	if c.JWTPrivateKeyFile == "" && c.JWTHMACSecret == "" {
+		errs = append(errs, errors.New("one of JWT_PRIVATE_KEY_FILE or JWT_HMAC_SECRET must be set to issue tokens"))
+	}
+	if c.AccessTokenTTL <= 0 {
+		errs = append(errs, errors.New("ACCESS_TOKEN_TTL must be positive"))
+	}
+	if c.RefreshTokenTTL <= 0 {
+		errs = append(errs, errors.New("REFRESH_TOKEN_TTL must be positive"))
+	}
+	// bcrypt accepts costs from 4 to 31.
+	if c.PasswordHashCost < 4 || c.PasswordHashCost > 31 {
+		errs = append(errs, errors.New("PASSWORD_HASH_COST must be between 4 and 31"))
+	}
+	return errors.Join(errs...)
+}
 
-	log.Printf("Configuration loaded: ENV=%s, PORT=%s, DB=%s@%s:%s/%s",
-		cfg.Env, cfg.Port, cfg.DBUser, cfg.DBHost, cfg.DBPort, cfg.DBName)
+// Validate reports every invalid tracing field.
+func (c *TracingConfig) Validate() error {
+	var errs []error
+	exporters := []string{tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterFile, tracing.ExporterOTLP}
+	if !slices.Contains(exporters, c.Exporter) {
+		errs = append(errs, fmt.Errorf("TRACING_EXPORTER %q must be one of none, stdout, file or otlp", c.Exporter))
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		errs = append(errs, errors.New("TRACING_SAMPLE_RATIO must be between 0 and 1"))
+	}
+	return errors.Join(errs...)
+}
 
-	return cfg
+// Validate reports every invalid idempotency field.
+func (c *IdempotencyConfig) Validate() error {
+	var errs []error
+	if c.TTL <= 0 || c.LockTimeout <= 0 || c.PurgeInterval <= 0 {
+		errs = append(errs, errors.New(
+			"IDEMPOTENCY_TTL, IDEMPOTENCY_LOCK_TIMEOUT and IDEMPOTENCY_PURGE_INTERVAL must be positive"))
+	}
+	if c.LockTimeout > c.TTL {
+		errs = append(errs, errors.New("IDEMPOTENCY_LOCK_TIMEOUT must not exceed IDEMPOTENCY_TTL"))
+	}
+	return errors.Join(errs...)
 }
 
-// mustGetEnv reads an environment variable or panics if not found.
-func mustGetEnv(key string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		panic(fmt.Sprintf("required environment variable %s is not set", key))
+// Validate reports every invalid rate limit field.
+func (c *RateLimitConfig) Validate() error {
+	var errs []error
+	if c.Store != ratelimit.StoreMemory && c.Store != ratelimit.StorePostgres {
+		errs = append(errs, fmt.Errorf("RATE_LIMIT_STORE %q must be memory or postgres", c.Store))
+	}
+	if c.Requests < 0 || c.IPRequests < 0 || c.AuthRequests < 0 {
+		errs = append(errs, errors.New(
+			"RATE_LIMIT_REQUESTS, RATE_LIMIT_IP_REQUESTS and RATE_LIMIT_AUTH_REQUESTS must not be negative"))
 	}
-	return value
+	if c.Window <= 0 || c.IPWindow <= 0 || c.AuthWindow <= 0 || c.PurgeInterval <= 0 {
+		errs = append(errs, errors.New(
+			"RATE_LIMIT_WINDOW, RATE_LIMIT_IP_WINDOW, RATE_LIMIT_AUTH_WINDOW and RATE_LIMIT_PURGE_INTERVAL must be positive"))
+	}
+	return errors.Join(errs...)
 }
 
-// getEnvOrDefault reads an environment variable or returns default value.
-func getEnvOrDefault(key, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
+// URL builds the PostgreSQL connection string.
+func (c *DatabaseConfig) URL() string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     net.JoinHostPort(c.Host, strconv.Itoa(c.Port)),
+		Path:     "/" + c.Name,
+		RawQuery: url.Values{"sslmode": {c.SSLMode}, "timezone": {"UTC"}}.Encode(),
 	}
-	return value
+	return u.String()
+}
+
+// String renders the configuration for logging, with secrets redacted.
+func (c *Config) String() string {
+	return describe(c)
 }
 
-// GetDatabaseURL constructs PostgreSQL connection string.
-func (c *Config) GetDatabaseURL() string {
-	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s&timezone=UTC",
-		c.DBUser, c.DBPassword, c.DBHost, c.DBPort, c.DBName, c.DBSSLMode)
+// validatePort reports a port outside the TCP range. Missing ports are left to the
+// required check.
+func validatePort(name string, port int) error {
+	if port < 0 || port > 65535 {
+		return fmt.Errorf("%s %d is not a valid port", name, port)
+	}
+	return nil
 }
@@ -0,0 +1,268 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// configFileEnv names the config file when the -config flag is not given.
+const configFileEnv = "CONFIG_FILE"
+
+// field is a configurable leaf of a config struct.
+type field struct {
+	key      string // Dotted file key, also the flag name.
+	env      string
+	def      string
+	required bool
+	secret   bool
+	value    reflect.Value
+}
+
+// load fills cfg, a pointer to a config struct, from its defaults, the config file, the
+// environment and the flags in args, in that order. prefix is the file key of cfg when
+// it is a single section, so it shares the file and flag names of the full Config.
+// Every malformed value is reported.
+func load(cfg any, prefix string, args []string) error {
+	// Try to load .env.local file (ignore error if file doesn't exist).
+	_ = godotenv.Load(".env.local")
+
+	fields := collectFields(reflect.ValueOf(cfg).Elem(), prefix)
+	byKey := make(map[string]field, len(fields))
+	for _, f := range fields {
+		byKey[f.key] = f
+	}
+
+	// Flags are parsed first to find -config, but applied last.
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv(configFileEnv), "YAML or TOML config file")
+	flagValues := make(map[string]string)
+	for _, f := range fields {
+		usage := "overrides " + f.env
+		record := func(s string) error {
+			flagValues[f.key] = s
+			return nil
+		}
+		if f.value.Kind() == reflect.Bool {
+			fs.BoolFunc(f.key, usage, record)
+		} else {
+			fs.Func(f.key, usage, record)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	var errs []error
+
+	for _, f := range fields {
+		if f.def != "" {
+			if err := setField(f.value, f.def); err != nil {
+				errs = append(errs, fmt.Errorf("default of %s: %w", f.key, err))
+			}
+		}
+	}
+
+	if *configFile != "" {
+		values, err := readFile(*configFile)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for key, raw := range values {
+			f, ok := byKey[key]
+			if !ok {
+				// A section loader shares the file of the full configuration.
+				if prefix == "" || strings.HasPrefix(key, prefix+".") {
+					errs = append(errs, fmt.Errorf("config file: unknown key %s", key))
+				}
+				continue
+			}
+			if err := setField(f.value, raw); err != nil {
+				errs = append(errs, fmt.Errorf("config file %s: %w", key, err))
+			}
+		}
+	}
+
+	for _, f := range fields {
+		if raw := os.Getenv(f.env); raw != "" {
+			if err := setField(f.value, raw); err != nil {
+				errs = append(errs, fmt.Errorf("environment variable %s: %w", f.env, err))
+			}
+		}
+	}
+
+	for key, raw := range flagValues {
+		if err := setField(byKey[key].value, raw); err != nil {
+			errs = append(errs, fmt.Errorf("flag -%s: %w", key, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// collectFields returns the leaves of the struct v, keyed below prefix.
+func collectFields(v reflect.Value, prefix string) []field {
+	var fields []field
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		key := sf.Tag.Get("config")
+		if key == "" {
+			continue
+		}
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if sf.Type.Kind() == reflect.Struct && sf.Type != reflect.TypeOf(time.Duration(0)) {
+			fields = append(fields, collectFields(v.Field(i), key)...)
+			continue
+		}
+		fields = append(fields, field{
+			key:      key,
+			env:      sf.Tag.Get("env"),
+			def:      sf.Tag.Get("default"),
+			required: sf.Tag.Get("required") == "true",
+			secret:   sf.Tag.Get("secret") == "true",
+			value:    v.Field(i),
+		})
+	}
+	return fields
+}
+
+// setField parses raw into v according to its type. Lists are comma-separated.
+func setField(v reflect.Value, raw string) error {
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a valid duration", raw)
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a valid integer", raw)
+		}
+		v.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a valid number", raw)
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a valid boolean", raw)
+		}
+		v.SetBool(b)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported config type %s", v.Type())
+	}
+	return nil
+}
+
+// readFile reads a YAML or TOML config file into flattened, dotted keys.
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	doc := make(map[string]any)
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &doc)
+	case ".toml":
+		err = toml.Unmarshal(data, &doc)
+	default:
+		return nil, fmt.Errorf("config file %s: unsupported format %q", path, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	values := make(map[string]string)
+	flatten(doc, "", values)
+	return values, nil
+}
+
+// flatten stores the scalar and list values of doc under dotted keys.
+func flatten(doc map[string]any, prefix string, values map[string]string) {
+	for k, v := range doc {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		switch v := v.(type) {
+		case map[string]any:
+			flatten(v, key, values)
+		case []any:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			values[key] = strings.Join(items, ",")
+		default:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+}
+
+// validateRequired reports every required field of cfg that is still empty.
+func validateRequired(cfg any) error {
+	var errs []error
+	for _, f := range collectFields(reflect.ValueOf(cfg).Elem(), "") {
+		if f.required && f.value.IsZero() {
+			errs = append(errs, fmt.Errorf("%s is required", f.env))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// describe renders cfg as key=value pairs, masking secrets that are set.
+func describe(cfg any) string {
+	fields := collectFields(reflect.ValueOf(cfg).Elem(), "")
+	pairs := make([]string, len(fields))
+	for i, f := range fields {
+		var value string
+		switch {
+		case f.secret && !f.value.IsZero():
+			value = "***"
+		case f.value.Kind() == reflect.Slice:
+			value = strings.Join(f.value.Interface().([]string), ",")
+		default:
+			value = fmt.Sprint(f.value.Interface())
+		}
+		pairs[i] = f.key + "=" + value
+	}
+	return strings.Join(pairs, " ")
+}
@@ -4,6 +4,9 @@ package constants
 type contextKey string
 
 const (
-	ContextKeyUserID contextKey = "user_id"
-	ContextKeyTrID   contextKey = "tr_id"
+	ContextKeyUserID       contextKey = "user_id"
+	ContextKeyTrID         contextKey = "tr_id"
+	ContextKeyPermissions  contextKey = "permissions"
+	ContextKeyAPIKeyScopes contextKey = "api_key_scopes"
+	ContextKeyAPIKeyID     contextKey = "api_key_id"
 )
@@ -10,10 +10,20 @@ const (
 	UnknownError ErrorCode = "0000" // HTTP 200 OK.
 	// Client errors (04xx).
 	InvalidParameter = "0400" // HTTP 400 Bad Request.
+	Unauthorized     = "0401" // HTTP 401 Unauthorized.
+	Forbidden        = "0403" // HTTP 403 Forbidden.
 	NotFound         = "0404" // HTTP 404 Not Found.
 	ConstraintError  = "0409" // HTTP 409 Conflict.
+	PreconditionFail = "0412" // HTTP 412 Precondition Failed.
+	PayloadTooLarge  = "0413" // HTTP 413 Content Too Large.
+	UnsupportedMedia = "0415" // HTTP 415 Unsupported Media Type.
+	Unprocessable    = "0422" // HTTP 422 Unprocessable Content.
+	PreconditionReq  = "0428" // HTTP 428 Precondition Required.
+	TooManyRequests  = "0429" // HTTP 429 Too Many Requests.
+	Canceled         = "0499" // HTTP 499 Client Closed Request (nginx convention).
 
 	// Server errors (05xx).
 	InternalError = "0500" // HTTP 500 Internal Server Error.
 	DatabaseError = "0500" // HTTP 500 Internal Server Error.
+	Timeout       = "0504" // HTTP 504 Gateway Timeout.
 )
@@ -4,31 +4,99 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log"
+	"strconv"
+	"time"
 
 	"entgo.io/ent/dialect"
 	entsql "entgo.io/ent/dialect/sql"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 
 	"github.com/wonjinsin/go-boilerplate/internal/config"
 	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent"
+	// Register schema hooks and interceptors (e.g. soft delete) with the generated client.
+	_ "github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/runtime"
 )
 
+// maxConnectBackoff caps the wait between startup connection attempts.
+const maxConnectBackoff = 30 * time.Second
+
+// DB holds the connection pools behind the ent client, for statistics and health checks.
+type DB struct {
+	Primary *sql.DB
+	// Replica is nil unless a read replica is configured.
+	Replica *sql.DB
+}
+
 // NewEntClient creates a new EntGo client with PostgreSQL connection.
-func NewEntClient(cfg *config.Config) (*ent.Client, error) {
-	// Open database connection.
-	db, err := sql.Open("pgx", cfg.GetDatabaseURL())
+// When a replica is configured, read-only statements outside transactions whose context
+// opts in with ReadFromReplica are routed to it. Closing the client closes every pool.
+func NewEntClient(cfg *config.DatabaseConfig) (*ent.Client, *DB, error) {
+	primary, err := openDB(cfg, cfg.URL())
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
+	pools := &DB{Primary: primary}
+	drv := dialect.Driver(entsql.OpenDB(dialect.Postgres, primary))
 
-	// Test connection with context.
-	ctx := context.Background()
-	if err := db.PingContext(ctx); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+	if cfg.ReplicaURL != "" {
+		replica, err := openDB(cfg, cfg.ReplicaURL)
+		if err != nil {
+			_ = primary.Close()
+			return nil, nil, fmt.Errorf("failed to connect to database replica: %w", err)
+		}
+		pools.Replica = replica
+		drv = newRoutingDriver(drv, entsql.OpenDB(dialect.Postgres, replica))
 	}
 
-	// Create ent client.
-	drv := entsql.OpenDB(dialect.Postgres, db)
-	client := ent.NewClient(ent.Driver(drv))
+	// Create ent client; every statement is traced.
+	client := ent.NewClient(ent.Driver(newTracingDriver(drv)))
+
+	return client, pools, nil
+}
 
-	return client, nil
+// openDB opens a pgx connection pool with the configured limits and waits until the
+// database accepts connections.
+func openDB(cfg *config.DatabaseConfig, dsn string) (*sql.DB, error) {
+	connConfig, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid connection string: %w", err)
+	}
+	if cfg.StatementTimeout > 0 {
+		connConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
+	}
+
+	db := stdlib.OpenDB(*connConfig)
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	if err := pingWithRetry(db, connConfig.Host, cfg.ConnectRetries, cfg.ConnectRetryBackoff); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// pingWithRetry pings db until it answers, retrying with exponential backoff so the
+// server can start alongside its database.
+func pingWithRetry(db *sql.DB, host string, retries int, backoff time.Duration) error {
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		err := db.PingContext(ctx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if attempt >= retries {
+			return fmt.Errorf("failed to ping database: %w", err)
+		}
+
+		log.Printf("database %s not ready (attempt %d/%d), retrying in %s: %v",
+			host, attempt+1, retries+1, backoff, err)
+		time.Sleep(backoff)
+		backoff = min(backoff*2, maxConnectBackoff)
+	}
 }
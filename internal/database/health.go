@@ -0,0 +1,39 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/wonjinsin/go-boilerplate/pkg/health"
+)
+
+// PingCheck reports whether the database accepts connections.
+func PingCheck(db *sql.DB) health.CheckFunc {
+	return func(ctx context.Context) error {
+		if err := db.PingContext(ctx); err != nil {
+			return fmt.Errorf("failed to ping database: %w", err)
+		}
+		return nil
+	}
+}
+
+// MigrationCheck reports whether migrations have been applied and the last one completed.
+// A dirty version means a migration failed halfway and the schema needs manual repair.
+func MigrationCheck(db *sql.DB) health.CheckFunc {
+	return func(ctx context.Context) error {
+		var (
+			version int64
+			dirty   bool
+		)
+		err := db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").
+			Scan(&version, &dirty)
+		if err != nil {
+			return fmt.Errorf("failed to read migration version: %w", err)
+		}
+		if dirty {
+			return fmt.Errorf("migration version %d is dirty", version)
+		}
+		return nil
+	}
+}
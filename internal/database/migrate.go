@@ -0,0 +1,123 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	atlasmigrate "ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/sqltool"
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	entschema "entgo.io/ent/dialect/sql/schema"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	"github.com/wonjinsin/go-boilerplate/internal/config"
+	entmigrate "github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/migrate"
+	"github.com/wonjinsin/go-boilerplate/migrations"
+)
+
+// MigrationFile is a generated golang-migrate file.
+type MigrationFile struct {
+	// Name is <name>.up.sql or <name>.down.sql, without a version prefix.
+	Name    string
+	Content []byte
+}
+
+// OpenMigrationDB opens a connection pool for running migrations. Schema changes may
+// legitimately run long, so the statement timeout is not applied.
+func OpenMigrationDB(cfg *config.DatabaseConfig) (*sql.DB, error) {
+	migrationCfg := *cfg
+	migrationCfg.StatementTimeout = 0
+
+	db, err := openDB(&migrationCfg, migrationCfg.URL())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return db, nil
+}
+
+// OpenScratchDB opens a connection pool to the disposable database at url, used to
+// apply migrations before diffing the schema. No statement timeout is applied.
+func OpenScratchDB(url string) (*sql.DB, error) {
+	db, err := openDB(&config.DatabaseConfig{}, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to scratch database: %w", err)
+	}
+	return db, nil
+}
+
+// NewMigrator returns a migrator over the embedded migrations. It takes ownership of db,
+// which Close closes. Every change runs under a PostgreSQL advisory lock, so concurrent
+// instances apply migrations one at a time.
+func NewMigrator(db *sql.DB) (*migrate.Migrate, error) {
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	drv, err := pgx.WithInstance(db, &pgx.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration driver: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", src, "pgx5", drv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migrator: %w", err)
+	}
+	return m, nil
+}
+
+// MigrateUp applies all pending migrations on a dedicated connection.
+func MigrateUp(cfg *config.DatabaseConfig) error {
+	db, err := OpenMigrationDB(cfg)
+	if err != nil {
+		return err
+	}
+	m, err := NewMigrator(db)
+	if err != nil {
+		_ = db.Close()
+		return err
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+	return nil
+}
+
+// DiffSchema compares the ent schema with the schema of db, which should have all
+// migrations applied, and returns the up and down files that resolve the difference.
+// No files means the migrations match the ent schema. Dropped columns and indexes
+// count as differences.
+func DiffSchema(ctx context.Context, db *sql.DB, name string) ([]MigrationFile, error) {
+	dir := &atlasmigrate.MemDir{}
+	err := entmigrate.NewSchema(entsql.OpenDB(dialect.Postgres, db)).NamedDiff(ctx, name,
+		entschema.WithDir(dir),
+		entschema.WithFormatter(sqltool.GolangMigrateFormatter),
+		entschema.WithDialect(dialect.Postgres),
+		entschema.WithMigrationMode(entschema.ModeInspect),
+		entschema.WithDropColumn(true),
+		entschema.WithDropIndex(true),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff schema: %w", err)
+	}
+
+	files, err := dir.Files()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]MigrationFile, len(files))
+	for i, f := range files {
+		// The formatter prefixes a timestamp; callers choose the version.
+		_, base, _ := strings.Cut(f.Name(), "_")
+		result[i] = MigrationFile{Name: base, Content: f.Bytes()}
+	}
+	return result, nil
+}
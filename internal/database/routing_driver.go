@@ -0,0 +1,80 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"entgo.io/ent/dialect"
+)
+
+type replicaReadKey struct{}
+
+// ReadFromReplica returns a context whose read-only statements outside a transaction may
+// be served by the replica. Only reads that tolerate replication lag should use it;
+// everything else reads from the primary.
+func ReadFromReplica(parent context.Context) context.Context {
+	return context.WithValue(parent, replicaReadKey{}, true)
+}
+
+// routingDriver is an ent driver that sends read-only statements issued outside a
+// transaction to a replica when the context opts in with ReadFromReplica. Writes,
+// locking reads, all transactions and every other read use the primary.
+type routingDriver struct {
+	dialect.Driver // Primary.
+	replica        dialect.Driver
+}
+
+// newRoutingDriver combines a primary and a read replica into one driver.
+func newRoutingDriver(primary, replica dialect.Driver) dialect.Driver {
+	return &routingDriver{Driver: primary, replica: replica}
+}
+
+// Query runs opted-in read-only statements on the replica and the rest on the primary.
+// ent issues INSERT ... RETURNING through Query as well.
+func (d *routingDriver) Query(ctx context.Context, query string, args, v any) error {
+	if replica, _ := ctx.Value(replicaReadKey{}).(bool); replica && isReadOnly(query) {
+		return d.replica.Query(ctx, query, args, v)
+	}
+	return d.Driver.Query(ctx, query, args, v)
+}
+
+// QueryContext runs raw statements like Query.
+func (d *routingDriver) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if replica, _ := ctx.Value(replicaReadKey{}).(bool); replica && isReadOnly(query) {
+		return queryContext(ctx, d.replica, query, args...)
+	}
+	return queryContext(ctx, d.Driver, query, args...)
+}
+
+// BeginTx starts a transaction with options on the primary.
+func (d *routingDriver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("driver does not support BeginTx")
+	}
+	return drv.BeginTx(ctx, opts)
+}
+
+// Close closes both connection pools.
+func (d *routingDriver) Close() error {
+	return errors.Join(d.Driver.Close(), d.replica.Close())
+}
+
+// isReadOnly reports whether a statement can be served by a replica.
+func isReadOnly(query string) bool {
+	if statementVerb(query) != "SELECT" {
+		return false
+	}
+	upper := strings.ToUpper(query)
+	for _, lock := range []string{" FOR UPDATE", " FOR NO KEY UPDATE", " FOR SHARE", " FOR KEY SHARE"} {
+		if strings.Contains(upper, lock) {
+			return false
+		}
+	}
+	return true
+}
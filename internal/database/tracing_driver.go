@@ -0,0 +1,173 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"entgo.io/ent/dialect"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wonjinsin/go-boilerplate/pkg/tracing"
+)
+
+// tracingDriver is an ent driver that records a client span for every SQL statement
+// and one span per transaction. Statement arguments are not recorded.
+type tracingDriver struct {
+	dialect.Driver
+}
+
+// newTracingDriver wraps drv with tracing.
+func newTracingDriver(drv dialect.Driver) dialect.Driver {
+	return &tracingDriver{Driver: drv}
+}
+
+// Exec traces and calls the underlying driver Exec method.
+func (d *tracingDriver) Exec(ctx context.Context, query string, args, v any) error {
+	ctx, span := startStatementSpan(ctx, query)
+	defer span.End()
+
+	err := d.Driver.Exec(ctx, query, args, v)
+	tracing.RecordError(span, err)
+	return err
+}
+
+// Query traces and calls the underlying driver Query method.
+func (d *tracingDriver) Query(ctx context.Context, query string, args, v any) error {
+	ctx, span := startStatementSpan(ctx, query)
+	defer span.End()
+
+	err := d.Driver.Query(ctx, query, args, v)
+	tracing.RecordError(span, err)
+	return err
+}
+
+// QueryContext traces and calls the underlying driver QueryContext method, which ent's
+// Client.QueryContext uses for raw statements.
+func (d *tracingDriver) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, span := startStatementSpan(ctx, query)
+	defer span.End()
+
+	rows, err := queryContext(ctx, d.Driver, query, args...)
+	tracing.RecordError(span, err)
+	return rows, err
+}
+
+// Tx starts a traced transaction.
+func (d *tracingDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	return d.BeginTx(ctx, nil)
+}
+
+// BeginTx starts a traced transaction with options. ent relies on it for isolation levels.
+func (d *tracingDriver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("driver does not support BeginTx")
+	}
+
+	ctx, span := tracing.Tracer().Start(ctx, "db.transaction",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("db.system", dialect.Postgres)),
+	)
+	if opts != nil {
+		span.SetAttributes(attribute.String("db.isolation_level", opts.Isolation.String()))
+	}
+
+	tx, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		tracing.RecordError(span, err)
+		span.End()
+		return nil, err
+	}
+	return &tracingTx{Tx: tx, span: span}, nil
+}
+
+// tracingTx traces the statements of a transaction and ends the transaction span
+// on commit or rollback.
+type tracingTx struct {
+	dialect.Tx
+	span trace.Span
+}
+
+// Exec traces and calls the underlying transaction Exec method.
+func (t *tracingTx) Exec(ctx context.Context, query string, args, v any) error {
+	ctx, span := startStatementSpan(ctx, query)
+	defer span.End()
+
+	err := t.Tx.Exec(ctx, query, args, v)
+	tracing.RecordError(span, err)
+	return err
+}
+
+// Query traces and calls the underlying transaction Query method.
+func (t *tracingTx) Query(ctx context.Context, query string, args, v any) error {
+	ctx, span := startStatementSpan(ctx, query)
+	defer span.End()
+
+	err := t.Tx.Query(ctx, query, args, v)
+	tracing.RecordError(span, err)
+	return err
+}
+
+// QueryContext traces and calls the underlying transaction QueryContext method.
+func (t *tracingTx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, span := startStatementSpan(ctx, query)
+	defer span.End()
+
+	rows, err := queryContext(ctx, t.Tx, query, args...)
+	tracing.RecordError(span, err)
+	return rows, err
+}
+
+// Commit commits the transaction and ends its span.
+func (t *tracingTx) Commit() error {
+	err := t.Tx.Commit()
+	tracing.RecordError(t.span, err)
+	t.span.SetAttributes(attribute.String("db.transaction.outcome", "commit"))
+	t.span.End()
+	return err
+}
+
+// Rollback rolls back the transaction and ends its span.
+func (t *tracingTx) Rollback() error {
+	err := t.Tx.Rollback()
+	tracing.RecordError(t.span, err)
+	t.span.SetAttributes(attribute.String("db.transaction.outcome", "rollback"))
+	t.span.End()
+	return err
+}
+
+// queryContext runs a raw statement on drv, which is a driver or a transaction.
+func queryContext(ctx context.Context, drv any, query string, args ...any) (*sql.Rows, error) {
+	q, ok := drv.(interface {
+		QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("driver does not support QueryContext")
+	}
+	return q.QueryContext(ctx, query, args...)
+}
+
+// startStatementSpan starts a span named after the statement's SQL verb, e.g. "SELECT".
+func startStatementSpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	operation := statementVerb(query)
+
+	return tracing.Tracer().Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", dialect.Postgres),
+			attribute.String("db.operation", operation),
+			attribute.String("db.statement", query),
+		),
+	)
+}
+
+// statementVerb returns the upper-cased leading keyword of a SQL statement.
+func statementVerb(query string) string {
+	verb, _, _ := strings.Cut(strings.TrimSpace(query), " ")
+	return strings.ToUpper(verb)
+}
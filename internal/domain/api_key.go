@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+)
+
+// APIKey is a long-lived credential for service-to-service callers.
+// The key acts on behalf of its owner, limited to its scopes.
+type APIKey struct {
+	ID         int
+	OwnerID    int
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     Permissions
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// APIKeys is a list of API keys.
+type APIKeys []*APIKey
+
+// NewAPIKey creates an API key record for the hashed secret.
+func NewAPIKey(
+	ownerID int,
+	name, prefix, keyHash string,
+	scopes Permissions,
+	expiresAt *time.Time,
+	now time.Time,
+) (*APIKey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New(constants.InvalidParameter, "api key name is required", nil)
+	}
+	if len(scopes) == 0 {
+		return nil, errors.New(constants.InvalidParameter, "api key needs at least one scope", nil)
+	}
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, errors.New(constants.InvalidParameter, "api key expiry must be in the future", nil)
+	}
+	return &APIKey{
+		OwnerID:   ownerID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   keyHash,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}, nil
+}
+
+// IsRevoked reports whether the key has been revoked.
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// IsExpired reports whether the key is past its expiry at now. Keys without expiry never expire.
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
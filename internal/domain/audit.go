@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+// AuditEntityUser names users in audit events.
+const AuditEntityUser = "user"
+
+// AuditOperation is the kind of change an audit event records.
+type AuditOperation string
+
+const (
+	AuditCreate  AuditOperation = "create"
+	AuditUpdate  AuditOperation = "update"
+	AuditDelete  AuditOperation = "delete"
+	AuditRestore AuditOperation = "restore"
+)
+
+// AuditEvent records one change of an entity. Before and After hold the changed fields;
+// a create has no Before and a permanent delete no After.
+type AuditEvent struct {
+	ID        int
+	Entity    string
+	EntityID  int
+	Operation AuditOperation
+	// Actor is the ID of the authenticated user that made the change, empty for system changes.
+	Actor     string
+	TrID      string
+	Before    map[string]any
+	After     map[string]any
+	CreatedAt time.Time
+}
+
+type AuditEvents []*AuditEvent
+
+// AuditQuery selects the events of an entity type, optionally of one entity, newest first.
+type AuditQuery struct {
+	Entity string
+	// EntityID is zero for every entity of the type.
+	EntityID int
+	Offset   int
+	Limit    int
+}
+
+// AuditPage is one page of audit events.
+type AuditPage struct {
+	Events AuditEvents
+	Total  int
+}
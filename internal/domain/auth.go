@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// RefreshToken is a single-use credential for obtaining new access tokens.
+// Each refresh rotates the token within its family; presenting an already
+// rotated or revoked token revokes the whole family.
+type RefreshToken struct {
+	ID        int
+	UserID    int
+	FamilyID  string
+	TokenHash string
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// NewRefreshToken creates a refresh token record for the hashed secret.
+func NewRefreshToken(userID int, familyID, tokenHash string, now time.Time, ttl time.Duration) *RefreshToken {
+	return &RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: tokenHash,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+}
+
+// IsRevoked reports whether the token has been rotated or revoked.
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// IsExpired reports whether the token is past its expiry at now.
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// AuthTokens is the token pair returned on login and refresh.
+type AuthTokens struct {
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
@@ -0,0 +1,76 @@
+package domain
+
+import "time"
+
+// IdempotencyStatus is the state of a request sent with an idempotency key.
+type IdempotencyStatus string
+
+const (
+	// IdempotencyInProgress marks a key whose first request is still running.
+	IdempotencyInProgress IdempotencyStatus = "in_progress"
+	// IdempotencyCompleted marks a key whose response is stored for replay.
+	IdempotencyCompleted IdempotencyStatus = "completed"
+)
+
+// IdempotencyRecord tracks a request sent with an idempotency key and, once it completes,
+// the response replayed to retries.
+type IdempotencyRecord struct {
+	ID    int
+	Scope string // Caller that sent the key.
+	Key   string
+	// Fingerprint identifies the request, so a key reused for another request is detected.
+	Fingerprint string
+	Status      IdempotencyStatus
+
+	ResponseStatus int
+	// ResponseHeaders holds the response headers replayed with the body, by name.
+	ResponseHeaders map[string]string
+	ResponseBody    []byte
+
+	ExpiresAt time.Time
+	// LockedUntil is when the lease of an in-progress record ends unless renewed.
+	LockedUntil time.Time
+	CreatedAt   time.Time
+}
+
+// NewIdempotencyRecord creates an in-progress record that expires after ttl, leased to
+// its request for lease.
+func NewIdempotencyRecord(
+	scope, key, fingerprint string,
+	ttl, lease time.Duration,
+	now time.Time,
+) *IdempotencyRecord {
+	return &IdempotencyRecord{
+		Scope:       scope,
+		Key:         key,
+		Fingerprint: fingerprint,
+		Status:      IdempotencyInProgress,
+		ExpiresAt:   now.Add(ttl),
+		LockedUntil: now.Add(lease),
+		CreatedAt:   now,
+	}
+}
+
+// RenewLease extends the lease of the record to lease from now.
+func (r *IdempotencyRecord) RenewLease(lease time.Duration, now time.Time) {
+	r.LockedUntil = now.Add(lease)
+}
+
+// Complete stores the response to replay.
+func (r *IdempotencyRecord) Complete(status int, headers map[string]string, body []byte) {
+	r.Status = IdempotencyCompleted
+	r.ResponseStatus = status
+	r.ResponseHeaders = headers
+	r.ResponseBody = body
+}
+
+// IsExpired reports whether the record no longer applies at now.
+func (r *IdempotencyRecord) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// IsAbandoned reports whether the record is still in progress after its lease ended,
+// meaning the request holding it stopped renewing it and will not finish.
+func (r *IdempotencyRecord) IsAbandoned(now time.Time) bool {
+	return r.Status == IdempotencyInProgress && !now.Before(r.LockedUntil)
+}
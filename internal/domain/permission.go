@@ -0,0 +1,28 @@
+package domain
+
+import "slices"
+
+// Permissions understood by the use cases.
+const (
+	PermissionUsersRead   = "users:read"
+	PermissionUsersWrite  = "users:write"
+	PermissionUsersDelete = "users:delete"
+	// PermissionUsersAdmin covers deleted users: listing and restoring them.
+	PermissionUsersAdmin = "users:admin"
+	PermissionAuditRead  = "audit:read"
+)
+
+// Roles seeded by the migrations.
+const (
+	// RoleAdmin holds every permission.
+	RoleAdmin  = "admin"
+	RoleViewer = "viewer"
+)
+
+// Permissions is the set of permissions granted to a caller.
+type Permissions []string
+
+// Has reports whether the permission is granted.
+func (p Permissions) Has(permission string) bool {
+	return slices.Contains(p, permission)
+}
@@ -11,23 +11,96 @@ import (
 
 // User is an aggregate root.
 type User struct {
-	ID        int
-	Name      string
-	Email     string
-	CreatedAt time.Time
+	ID    int
+	Name  string
+	Email string
+	// PasswordHash is empty for users without password credentials.
+	PasswordHash string
+	CreatedAt    time.Time
+	DeletedAt    *time.Time
+	// Version is incremented by every saved change; it backs the user's ETag.
+	Version int
 }
 
 func NewUser(id int, name, email string, now time.Time) (*User, error) {
+	name, email, err := normalizeUserFields(name, email)
+	if err != nil {
+		return nil, err
+	}
+	return &User{ID: id, Name: name, Email: email, CreatedAt: now}, nil
+}
+
+// Update replaces the mutable fields of the user, applying the same rules as NewUser.
+func (u *User) Update(name, email string) error {
+	name, email, err := normalizeUserFields(name, email)
+	if err != nil {
+		return err
+	}
+	u.Name = name
+	u.Email = email
+	return nil
+}
+
+// CheckVersion returns a PreconditionFail error unless version is the current version of
+// the user. Zero matches any version.
+func (u *User) CheckVersion(version int) error {
+	if version != 0 && version != u.Version {
+		return errors.New(constants.PreconditionFail, "user has been modified", nil)
+	}
+	return nil
+}
+
+// normalizeUserFields normalizes and validates user name and email.
+func normalizeUserFields(name, email string) (string, string, error) {
 	name = utils.NormalizeName(name)
 	email = utils.NormalizeEmail(email)
 
 	if utils.IsEmptyOrWhitespace(name) || len(name) > pkgConstants.MaxNameLength {
-		return nil, errors.New(constants.InvalidParameter, "invalid name", nil)
+		return "", "", errors.New(constants.InvalidParameter, "invalid name", nil)
 	}
 	if !utils.IsValidEmail(email) {
-		return nil, errors.New(constants.InvalidParameter, "invalid email format", nil)
+		return "", "", errors.New(constants.InvalidParameter, "invalid email format", nil)
 	}
-	return &User{ID: id, Name: name, Email: email, CreatedAt: now}, nil
+	return name, email, nil
+}
+
+// PasswordHasher hashes and verifies user passwords.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Compare(hash, password string) error
+}
+
+// SetPassword validates the password and stores its hash.
+func (u *User) SetPassword(password string, hasher PasswordHasher) error {
+	if len(password) < pkgConstants.MinPasswordLength || len(password) > pkgConstants.MaxPasswordLength {
+		return errors.New(constants.InvalidParameter, "invalid password length", nil)
+	}
+	hash, err := hasher.Hash(password)
+	if err != nil {
+		return errors.Wrap(err, "failed to hash password")
+	}
+	u.PasswordHash = hash
+	return nil
+}
+
+// CheckPassword reports whether the password matches the stored hash. Users without a
+// password never match and return at once, so login paths that must not leak timing
+// compare against a dummy hash in that case.
+func (u *User) CheckPassword(password string, hasher PasswordHasher) bool {
+	if u.PasswordHash == "" {
+		return false
+	}
+	return hasher.Compare(u.PasswordHash, password) == nil
+}
+
+// IsDeleted reports whether the user has been soft-deleted.
+func (u *User) IsDeleted() bool {
+	return u.DeletedAt != nil
+}
+
+// CursorOf returns the keyset position of the user.
+func (u *User) CursorOf() *UserCursor {
+	return &UserCursor{CreatedAt: u.CreatedAt, ID: u.ID}
 }
 
 type Users []*User
@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+)
+
+// Filterable and sortable user fields.
+const (
+	UserFieldID        = "id"
+	UserFieldName      = "name"
+	UserFieldEmail     = "email"
+	UserFieldCreatedAt = "created_at"
+)
+
+// Filter operators.
+const (
+	FilterOpEq        = "eq"
+	FilterOpPrefix    = "prefix"
+	FilterOpContains  = "contains"
+	FilterOpIEq       = "ieq"
+	FilterOpIPrefix   = "iprefix"
+	FilterOpIContains = "icontains"
+	FilterOpGreater   = "gt"
+	FilterOpGreaterEq = "gte"
+	FilterOpLess      = "lt"
+	FilterOpLessEq    = "lte"
+)
+
+var (
+	textFilterOps = []string{
+		FilterOpEq, FilterOpPrefix, FilterOpContains,
+		FilterOpIEq, FilterOpIPrefix, FilterOpIContains,
+	}
+	timeFilterOps = []string{
+		FilterOpEq, FilterOpGreater, FilterOpGreaterEq, FilterOpLess, FilterOpLessEq,
+	}
+
+	// userFilterOps whitelists the operators allowed on each filterable field.
+	userFilterOps = map[string][]string{
+		UserFieldName:      textFilterOps,
+		UserFieldEmail:     textFilterOps,
+		UserFieldCreatedAt: timeFilterOps,
+	}
+
+	// userSortFields whitelists the fields users can be sorted by.
+	userSortFields = []string{UserFieldID, UserFieldName, UserFieldEmail, UserFieldCreatedAt}
+)
+
+// UserFilter is a single validated filter clause on a user field.
+// Text fields use Value; created_at uses Time.
+type UserFilter struct {
+	Field string
+	Op    string
+	Value string
+	Time  time.Time
+}
+
+// NewUserFilter validates a filter clause against the field and operator whitelist.
+func NewUserFilter(field, op, value string) (UserFilter, error) {
+	ops, ok := userFilterOps[field]
+	if !ok {
+		return UserFilter{}, errors.New(constants.InvalidParameter,
+			fmt.Sprintf("field %q is not filterable", field), nil)
+	}
+	if !slices.Contains(ops, op) {
+		return UserFilter{}, errors.New(constants.InvalidParameter,
+			fmt.Sprintf("operator %q is not supported on field %q", op, field), nil)
+	}
+
+	f := UserFilter{Field: field, Op: op, Value: value}
+	if field == UserFieldCreatedAt {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return UserFilter{}, errors.New(constants.InvalidParameter,
+				fmt.Sprintf("value %q of field %q is not an RFC 3339 timestamp", value, field), nil)
+		}
+		f.Time = t
+	}
+	return f, nil
+}
+
+// UserSort is a single validated sort key.
+type UserSort struct {
+	Field string
+	Desc  bool
+}
+
+// NewUserSort validates a sort key against the sortable field whitelist.
+func NewUserSort(field string, desc bool) (UserSort, error) {
+	if !slices.Contains(userSortFields, field) {
+		return UserSort{}, errors.New(constants.InvalidParameter,
+			fmt.Sprintf("field %q is not sortable", field), nil)
+	}
+	return UserSort{Field: field, Desc: desc}, nil
+}
+
+// UserCursor is the keyset position of a user in the stable (created_at, id) ordering.
+type UserCursor struct {
+	CreatedAt time.Time
+	ID        int
+}
+
+// UserListQuery describes which users to list and how to paginate them.
+// When After or Before is set, keyset pagination is used and Offset is ignored.
+// Keyset pagination is only defined for the default (created_at, id) ordering.
+type UserListQuery struct {
+	Offset         int
+	Limit          int
+	After          *UserCursor
+	Before         *UserCursor
+	IncludeDeleted bool
+	Filters        []UserFilter
+	Sort           []UserSort
+}
+
+// IsBackward reports whether the query pages backwards from a cursor.
+func (q UserListQuery) IsBackward() bool {
+	return q.Before != nil
+}
+
+// IsKeyset reports whether the query pages from a cursor.
+func (q UserListQuery) IsKeyset() bool {
+	return q.After != nil || q.Before != nil
+}
+
+// HasCustomSort reports whether the query overrides the default (created_at, id) ordering.
+func (q UserListQuery) HasCustomSort() bool {
+	return len(q.Sort) > 0
+}
+
+// UserPage is a page of users along with the positions of its neighbouring pages.
+type UserPage struct {
+	Users      Users
+	Total      int
+	NextCursor *UserCursor
+	PrevCursor *UserCursor
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/handler/http/dto"
+	"github.com/wonjinsin/go-boilerplate/internal/usecase"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+	"github.com/wonjinsin/go-boilerplate/pkg/logger"
+	"github.com/wonjinsin/go-boilerplate/pkg/utils"
+)
+
+// APIKeyController handles issuing, listing and revoking the caller's API keys.
+type APIKeyController struct {
+	svc usecase.APIKeyService
+}
+
+// NewAPIKeyController creates a new API key controller.
+func NewAPIKeyController(svc usecase.APIKeyService) *APIKeyController {
+	return &APIKeyController{svc: svc}
+}
+
+// CreateAPIKey handles issuing a key. The secret is only returned in this response.
+func (c *APIKeyController) CreateAPIKey(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	logger.LogInfo(ctx, "CreateAPIKey request received")
+
+	var req dto.CreateAPIKeyRequest
+	if err := utils.ParseJSONBody(r, &req); err != nil {
+		return err
+	}
+
+	k, key, err := c.svc.IssueAPIKey(ctx, req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	logger.LogInfo(ctx, "api key created successfully")
+	utils.WriteStandardJSON(w, r, http.StatusCreated, dto.ToCreateAPIKeyResponse(k, key))
+	return nil
+}
+
+// ListAPIKeys handles listing the caller's keys.
+func (c *APIKeyController) ListAPIKeys(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	logger.LogInfo(ctx, "ListAPIKeys request received")
+
+	keys, err := c.svc.ListAPIKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.LogInfo(ctx, "api keys listed successfully")
+	utils.WriteStandardJSON(w, r, http.StatusOK, dto.ToAPIKeyListResponse(keys))
+	return nil
+}
+
+// RevokeAPIKey handles revoking one of the caller's keys.
+func (c *APIKeyController) RevokeAPIKey(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	logger.LogInfo(ctx, "RevokeAPIKey request received")
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return errors.New(constants.InvalidParameter, "invalid api key id format", err)
+	}
+
+	if err := c.svc.RevokeAPIKey(ctx, id); err != nil {
+		return err
+	}
+
+	logger.LogInfo(ctx, "api key revoked successfully")
+	utils.WriteStandardJSON(w, r, http.StatusOK, nil)
+	return nil
+}
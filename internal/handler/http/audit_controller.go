@@ -0,0 +1,54 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/internal/handler/http/dto"
+	"github.com/wonjinsin/go-boilerplate/internal/usecase"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+	"github.com/wonjinsin/go-boilerplate/pkg/logger"
+	"github.com/wonjinsin/go-boilerplate/pkg/utils"
+)
+
+// AuditController handles audit log HTTP requests.
+type AuditController struct {
+	svc usecase.AuditService
+}
+
+// NewAuditController creates a new audit controller.
+func NewAuditController(svc usecase.AuditService) *AuditController {
+	return &AuditController{svc: svc}
+}
+
+// ListEvents handles listing the changes of an entity type, or of one entity when the
+// id query parameter is set.
+func (c *AuditController) ListEvents(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	logger.LogInfo(ctx, "ListEvents request received")
+
+	offset, limit := utils.ParsePagination(r)
+	q := domain.AuditQuery{Entity: r.URL.Query().Get("entity"), Offset: offset, Limit: limit}
+	if q.Entity == "" {
+		return errors.New(constants.InvalidParameter, "entity is required", nil)
+	}
+	if idStr := r.URL.Query().Get("id"); idStr != "" {
+		id, err := strconv.Atoi(idStr)
+		if err != nil || id <= 0 {
+			return errors.New(constants.InvalidParameter, "invalid entity id format", err)
+		}
+		q.EntityID = id
+	}
+
+	page, err := c.svc.ListEvents(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	logger.LogInfo(ctx, "audit events listed successfully")
+	response := dto.ToAuditListResponse(page, q.Offset, q.Limit)
+	utils.WriteStandardJSON(w, r, http.StatusOK, response)
+	return nil
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/wonjinsin/go-boilerplate/internal/handler/http/dto"
+	"github.com/wonjinsin/go-boilerplate/internal/usecase"
+	"github.com/wonjinsin/go-boilerplate/pkg/logger"
+	"github.com/wonjinsin/go-boilerplate/pkg/utils"
+)
+
+// AuthController handles login, token refresh and logout.
+type AuthController struct {
+	svc usecase.AuthService
+}
+
+// NewAuthController creates a new auth controller.
+func NewAuthController(svc usecase.AuthService) *AuthController {
+	return &AuthController{svc: svc}
+}
+
+// Login handles password login.
+func (c *AuthController) Login(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	logger.LogInfo(ctx, "Login request received")
+
+	var req dto.LoginRequest
+	if err := utils.ParseJSONBody(r, &req); err != nil {
+		return err
+	}
+
+	tokens, err := c.svc.Login(ctx, req.Email, req.Password)
+	if err != nil {
+		return err
+	}
+
+	logger.LogInfo(ctx, "user logged in successfully")
+	utils.WriteStandardJSON(w, r, http.StatusOK, dto.ToTokenResponse(tokens))
+	return nil
+}
+
+// Refresh handles refresh token rotation.
+func (c *AuthController) Refresh(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	logger.LogInfo(ctx, "Refresh request received")
+
+	var req dto.RefreshTokenRequest
+	if err := utils.ParseJSONBody(r, &req); err != nil {
+		return err
+	}
+
+	tokens, err := c.svc.Refresh(ctx, req.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	logger.LogInfo(ctx, "tokens refreshed successfully")
+	utils.WriteStandardJSON(w, r, http.StatusOK, dto.ToTokenResponse(tokens))
+	return nil
+}
+
+// Logout handles revoking the refresh token family of the presented token.
+func (c *AuthController) Logout(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	logger.LogInfo(ctx, "Logout request received")
+
+	var req dto.RefreshTokenRequest
+	if err := utils.ParseJSONBody(r, &req); err != nil {
+		return err
+	}
+
+	if err := c.svc.Logout(ctx, req.RefreshToken); err != nil {
+		return err
+	}
+
+	logger.LogInfo(ctx, "user logged out successfully")
+	utils.WriteStandardJSON(w, r, http.StatusOK, nil)
+	return nil
+}
@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+// CreateAPIKeyRequest represents the request payload for issuing an API key.
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Validate reports every invalid field.
+func (req *CreateAPIKeyRequest) Validate() error {
+	var v validation
+	v.required("name", req.Name)
+	v.minItems("scopes", req.Scopes, 1)
+	v.future("expires_at", req.ExpiresAt)
+	return v.err()
+}
+
+// APIKeyResponse represents an API key. The secret is never included.
+type APIKeyResponse struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreateAPIKeyResponse represents a newly issued key together with its secret,
+// which is only shown in this response.
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+// APIKeyListResponse represents the response payload for listing API keys.
+type APIKeyListResponse struct {
+	APIKeys []APIKeyResponse `json:"api_keys"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import "github.com/wonjinsin/go-boilerplate/internal/domain"
+
+// ToAPIKeyResponse converts domain.APIKey to APIKeyResponse.
+func ToAPIKeyResponse(k *domain.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     k.Scopes,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt,
+	}
+}
+
+// ToCreateAPIKeyResponse converts a newly issued domain.APIKey and its secret to CreateAPIKeyResponse.
+func ToCreateAPIKeyResponse(k *domain.APIKey, key string) CreateAPIKeyResponse {
+	return CreateAPIKeyResponse{APIKeyResponse: ToAPIKeyResponse(k), Key: key}
+}
+
+// ToAPIKeyListResponse converts domain.APIKeys to APIKeyListResponse.
+func ToAPIKeyListResponse(keys domain.APIKeys) APIKeyListResponse {
+	responses := make([]APIKeyResponse, len(keys))
+	for i, k := range keys {
+		responses[i] = ToAPIKeyResponse(k)
+	}
+	return APIKeyListResponse{APIKeys: responses}
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+// AuditEventResponse represents one change in the audit log.
+type AuditEventResponse struct {
+	ID        int            `json:"id"`
+	Entity    string         `json:"entity"`
+	EntityID  int            `json:"entity_id"`
+	Operation string         `json:"operation"`
+	Actor     string         `json:"actor,omitempty"`
+	TrID      string         `json:"trid,omitempty"`
+	Before    map[string]any `json:"before,omitempty"`
+	After     map[string]any `json:"after,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// AuditListResponse represents the response payload for listing audit events.
+type AuditListResponse struct {
+	Events []AuditEventResponse `json:"events"`
+	Total  int                  `json:"total"`
+	Offset int                  `json:"offset"`
+	Limit  int                  `json:"limit"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "github.com/wonjinsin/go-boilerplate/internal/domain"
+
+// ToAuditEventResponse converts domain.AuditEvent to AuditEventResponse.
+func ToAuditEventResponse(e *domain.AuditEvent) AuditEventResponse {
+	return AuditEventResponse{
+		ID:        e.ID,
+		Entity:    e.Entity,
+		EntityID:  e.EntityID,
+		Operation: string(e.Operation),
+		Actor:     e.Actor,
+		TrID:      e.TrID,
+		Before:    e.Before,
+		After:     e.After,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+// ToAuditListResponse converts domain.AuditPage to AuditListResponse.
+func ToAuditListResponse(page *domain.AuditPage, offset, limit int) AuditListResponse {
+	responses := make([]AuditEventResponse, len(page.Events))
+	for i, e := range page.Events {
+		responses[i] = ToAuditEventResponse(e)
+	}
+	return AuditListResponse{Events: responses, Total: page.Total, Offset: offset, Limit: limit}
+}
@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+// LoginRequest represents the request payload for password login.
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// Validate reports every missing field.
+func (req *LoginRequest) Validate() error {
+	var v validation
+	v.required("email", req.Email)
+	v.required("password", req.Password)
+	return v.err()
+}
+
+// RefreshTokenRequest represents the request payload for token refresh and logout.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Validate reports a missing refresh token.
+func (req *RefreshTokenRequest) Validate() error {
+	var v validation
+	v.required("refresh_token", req.RefreshToken)
+	return v.err()
+}
+
+// TokenResponse represents the response payload for issued tokens.
+type TokenResponse struct {
+	AccessToken           string    `json:"access_token"`
+	TokenType             string    `json:"token_type"`
+	ExpiresIn             int       `json:"expires_in"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/pkg/constants"
+)
+
+// ToTokenResponse converts domain.AuthTokens to TokenResponse.
+func ToTokenResponse(tokens *domain.AuthTokens) TokenResponse {
+	return TokenResponse{
+		AccessToken:           tokens.AccessToken,
+		TokenType:             constants.AuthSchemeBearer,
+		ExpiresIn:             int(time.Until(tokens.AccessTokenExpiresAt).Seconds()),
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
+	}
+}
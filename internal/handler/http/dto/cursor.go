@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+	"github.com/wonjinsin/go-boilerplate/pkg/utils"
+)
+
+// Cursor directions.
+const (
+	CursorNext = "next"
+	CursorPrev = "prev"
+)
+
+// userCursor is the signed payload of a user list cursor.
+type userCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        int       `json:"i"`
+	Direction string    `json:"d"`
+}
+
+// EncodeUserCursor converts a keyset position into an opaque signed cursor.
+// Returns an empty string when there is no position.
+func EncodeUserCursor(secret []byte, c *domain.UserCursor, direction string) (string, error) {
+	if c == nil {
+		return "", nil
+	}
+	return utils.EncodeCursor(secret, userCursor{
+		CreatedAt: c.CreatedAt,
+		ID:        c.ID,
+		Direction: direction,
+	})
+}
+
+// ApplyUserCursor verifies an opaque cursor and sets the matching keyset position on the query.
+func ApplyUserCursor(secret []byte, token string, q *domain.UserListQuery) error {
+	var c userCursor
+	if err := utils.DecodeCursor(secret, token, &c); err != nil {
+		return err
+	}
+
+	position := &domain.UserCursor{CreatedAt: c.CreatedAt, ID: c.ID}
+	switch c.Direction {
+	case CursorNext:
+		q.After = position
+	case CursorPrev:
+		q.Before = position
+	default:
+		return errors.New(constants.InvalidParameter, "invalid cursor direction", nil)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package dto
+
+import (
+	"encoding/base64"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+	"github.com/wonjinsin/go-boilerplate/pkg/utils"
+)
+
+func TestUserCursorRoundTrip(t *testing.T) {
+	secret := []byte("cursor-secret")
+	position := &domain.UserCursor{CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC), ID: 42}
+
+	tests := []struct {
+		name      string
+		direction string
+		want      domain.UserListQuery
+	}{
+		{name: "next", direction: CursorNext, want: domain.UserListQuery{After: position}},
+		{name: "prev", direction: CursorPrev, want: domain.UserListQuery{Before: position}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := EncodeUserCursor(secret, position, tt.direction)
+			if err != nil {
+				t.Fatalf("EncodeUserCursor() error = %v", err)
+			}
+
+			var q domain.UserListQuery
+			if err := ApplyUserCursor(secret, token, &q); err != nil {
+				t.Fatalf("ApplyUserCursor() error = %v", err)
+			}
+			if !reflect.DeepEqual(q, tt.want) {
+				t.Errorf("query = %+v, want %+v", q, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeUserCursorWithoutPosition(t *testing.T) {
+	token, err := EncodeUserCursor([]byte("cursor-secret"), nil, CursorNext)
+	if err != nil || token != "" {
+		t.Errorf("EncodeUserCursor(nil) = %q, %v, want empty token", token, err)
+	}
+}
+
+func TestApplyUserCursorRejectsTampering(t *testing.T) {
+	secret := []byte("cursor-secret")
+	position := &domain.UserCursor{CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), ID: 42}
+	token, err := EncodeUserCursor(secret, position, CursorNext)
+	if err != nil {
+		t.Fatalf("EncodeUserCursor() error = %v", err)
+	}
+	payload, signature, _ := strings.Cut(token, ".")
+
+	// forged is a validly encoded payload pointing elsewhere, paired with the original signature.
+	forged := base64.RawURLEncoding.EncodeToString([]byte(`{"t":"2025-01-02T03:04:05Z","i":1,"d":"next"}`))
+	// badDirection is correctly signed but carries an unknown direction.
+	badDirection, err := utils.EncodeCursor(secret, userCursor{CreatedAt: position.CreatedAt, ID: 42, Direction: "up"})
+	if err != nil {
+		t.Fatalf("EncodeCursor() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		secret []byte
+		token  string
+	}{
+		{name: "empty", secret: secret, token: ""},
+		{name: "no signature", secret: secret, token: payload},
+		{name: "forged payload", secret: secret, token: forged + "." + signature},
+		{name: "truncated signature", secret: secret, token: payload + "." + signature[:len(signature)-2]},
+		{name: "signature not base64", secret: secret, token: payload + ".!!!"},
+		{name: "other secret", secret: []byte("other-secret"), token: token},
+		{name: "unknown direction", secret: secret, token: badDirection},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q domain.UserListQuery
+			err := ApplyUserCursor(tt.secret, tt.token, &q)
+			if !errors.HasCode(err, constants.InvalidParameter) {
+				t.Fatalf("ApplyUserCursor() error = %v, want %s", err, constants.InvalidParameter)
+			}
+			if q.After != nil || q.Before != nil {
+				t.Errorf("query = %+v, want no position", q)
+			}
+		})
+	}
+}
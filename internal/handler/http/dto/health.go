@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+// HealthResponse represents the readiness of the service and its dependencies.
+type HealthResponse struct {
+	Status     string                     `json:"status"`
+	Components map[string]HealthComponent `json:"components,omitempty"`
+}
+
+// HealthComponent represents the last check of one dependency. Failure details are
+// logged rather than exposed.
+type HealthComponent struct {
+	Status    string    `json:"status"`
+	CheckedAt time.Time `json:"checked_at"`
+}
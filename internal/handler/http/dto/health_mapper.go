@@ -0,0 +1,15 @@
+package dto
+
+import "github.com/wonjinsin/go-boilerplate/pkg/health"
+
+// ToHealthResponse converts health.Report to HealthResponse.
+func ToHealthResponse(report health.Report) HealthResponse {
+	components := make(map[string]HealthComponent, len(report.Components))
+	for name, result := range report.Components {
+		components[name] = HealthComponent{
+			Status:    string(result.Status),
+			CheckedAt: result.CheckedAt,
+		}
+	}
+	return HealthResponse{Status: string(report.Status), Components: components}
+}
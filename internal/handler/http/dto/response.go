@@ -1,6 +0,0 @@
-package dto
-
-// ErrorResult represents the error result structure.
-type ErrorResult struct {
-	Msg string `json:"msg"`
-}
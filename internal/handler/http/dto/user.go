@@ -1,25 +1,114 @@
 package dto
 
-import "time"
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/wonjinsin/go-boilerplate/pkg/constants"
+)
 
 // CreateUserRequest represents the request payload for creating a user.
+// Password is optional; users without one cannot log in.
 type CreateUserRequest struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// Validate reports every invalid field.
+func (req *CreateUserRequest) Validate() error {
+	var v validation
+	validateUserFields(&v, req.Name, req.Email)
+	if req.Password != "" {
+		v.length("password", req.Password, constants.MinPasswordLength, constants.MaxPasswordLength)
+	}
+	return v.err()
+}
+
+// UpdateUserRequest represents the request payload for replacing a user.
+type UpdateUserRequest struct {
 	Name  string `json:"name"`
 	Email string `json:"email"`
 }
 
+// Validate reports every invalid field.
+func (req *UpdateUserRequest) Validate() error {
+	var v validation
+	validateUserFields(&v, req.Name, req.Email)
+	return v.err()
+}
+
+// PatchUserRequest represents a JSON merge-patch payload for a user.
+// Absent fields are left unchanged; null is rejected because every user field is required.
+type PatchUserRequest struct {
+	Name  *string `json:"name"`
+	Email *string `json:"email"`
+}
+
+// UnmarshalJSON rejects explicit nulls, which merge-patch would treat as field removal,
+// and unknown fields.
+func (p *PatchUserRequest) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	var v validation
+	for _, field := range slices.Sorted(maps.Keys(raw)) {
+		if string(raw[field]) == "null" {
+			v.add(field, RuleNotNull, "cannot be removed")
+		}
+	}
+	if err := v.err(); err != nil {
+		return err
+	}
+
+	// A custom UnmarshalJSON does not inherit DisallowUnknownFields from the caller.
+	type alias PatchUserRequest
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode((*alias)(p))
+}
+
+// Validate reports every invalid field that is present.
+func (p *PatchUserRequest) Validate() error {
+	var v validation
+	if p.Name != nil && v.required("name", *p.Name) {
+		v.length("name", *p.Name, 1, constants.MaxNameLength)
+	}
+	if p.Email != nil && v.required("email", *p.Email) {
+		v.email("email", *p.Email)
+	}
+	return v.err()
+}
+
+// validateUserFields checks the name and email shared by user payloads.
+func validateUserFields(v *validation, name, email string) {
+	if v.required("name", name) {
+		v.length("name", name, 1, constants.MaxNameLength)
+	}
+	if v.required("email", email) {
+		v.email("email", email)
+	}
+}
+
 // UserResponse represents the response payload for user data.
 type UserResponse struct {
-	ID        int       `json:"id"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        int        `json:"id"`
+	Name      string     `json:"name"`
+	Email     string     `json:"email"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 // UserListResponse represents the response payload for user list.
 type UserListResponse struct {
-	Users  []UserResponse `json:"users"`
-	Total  int            `json:"total"`
-	Offset int            `json:"offset"`
-	Limit  int            `json:"limit"`
+	Users      []UserResponse `json:"users"`
+	Total      int            `json:"total"`
+	Offset     int            `json:"offset"`
+	Limit      int            `json:"limit"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+	PrevCursor string         `json:"prev_cursor,omitempty"`
 }
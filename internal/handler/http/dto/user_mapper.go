@@ -9,20 +9,36 @@ func ToUserResponse(user *domain.User) UserResponse {
 		Name:      user.Name,
 		Email:     user.Email,
 		CreatedAt: user.CreatedAt,
+		DeletedAt: user.DeletedAt,
 	}
 }
 
-// ToUserListResponse converts domain.Users to UserListResponse.
-func ToUserListResponse(users domain.Users, total, offset, limit int) UserListResponse {
-	userResponses := make([]UserResponse, len(users))
-	for i, user := range users {
+// ToUserListResponse converts domain.UserPage to UserListResponse, signing its cursors.
+func ToUserListResponse(
+	page *domain.UserPage,
+	offset, limit int,
+	cursorSecret []byte,
+) (UserListResponse, error) {
+	userResponses := make([]UserResponse, len(page.Users))
+	for i, user := range page.Users {
 		userResponses[i] = ToUserResponse(user)
 	}
 
-	return UserListResponse{
-		Users:  userResponses,
-		Total:  total,
-		Offset: offset,
-		Limit:  limit,
+	next, err := EncodeUserCursor(cursorSecret, page.NextCursor, CursorNext)
+	if err != nil {
+		return UserListResponse{}, err
+	}
+	prev, err := EncodeUserCursor(cursorSecret, page.PrevCursor, CursorPrev)
+	if err != nil {
+		return UserListResponse{}, err
 	}
+
+	return UserListResponse{
+		Users:      userResponses,
+		Total:      page.Total,
+		Offset:     offset,
+		Limit:      limit,
+		NextCursor: next,
+		PrevCursor: prev,
+	}, nil
 }
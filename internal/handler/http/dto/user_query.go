@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+)
+
+// ParseUserFilters parses filter query values into domain filters.
+// Each value holds exactly one clause of the form field:op:value, so values may
+// contain commas; repeat the parameter to combine clauses, e.g.
+// "filter=name:icontains:john&filter=created_at:gte:2025-01-01T00:00:00Z".
+func ParseUserFilters(values []string) ([]domain.UserFilter, error) {
+	var filters []domain.UserFilter
+	for _, clause := range values {
+		if strings.TrimSpace(clause) == "" {
+			continue
+		}
+
+		// Split on the first two colons only; timestamps contain colons themselves.
+		parts := strings.SplitN(clause, ":", 3)
+		if len(parts) != 3 {
+			return nil, errors.New(constants.InvalidParameter,
+				fmt.Sprintf("invalid filter clause %q: expected field:op:value", clause), nil)
+		}
+
+		f, err := domain.NewUserFilter(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), parts[2])
+		if err != nil {
+			msg := fmt.Sprintf("invalid filter clause %q: %s", clause, errors.PublicMessage(err))
+			return nil, errors.Wrap(err, msg, constants.InvalidParameter)
+		}
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
+
+// ParseUserSort parses a comma-separated sort specification such as "-created_at,name".
+// A leading "-" sorts the field in descending order.
+func ParseUserSort(value string) ([]domain.UserSort, error) {
+	var sorts []domain.UserSort
+	for _, key := range strings.Split(value, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+
+		field, desc := strings.CutPrefix(key, "-")
+		s, err := domain.NewUserSort(field, desc)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid sort key %q", key))
+		}
+		sorts = append(sorts, s)
+	}
+	return sorts, nil
+}
@@ -0,0 +1,134 @@
+package dto
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+)
+
+func TestParseUserFilters(t *testing.T) {
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		values []string
+		want   []domain.UserFilter
+		// wantErr is a substring of the public error message; empty means success.
+		wantErr string
+	}{
+		{name: "no filters"},
+		{name: "blank value skipped", values: []string{" "}},
+		{
+			name:   "single clause",
+			values: []string{"name:icontains:john"},
+			want:   []domain.UserFilter{{Field: "name", Op: "icontains", Value: "john"}},
+		},
+		{
+			name:   "value with commas",
+			values: []string{"name:eq:Doe, Jane"},
+			want:   []domain.UserFilter{{Field: "name", Op: "eq", Value: "Doe, Jane"}},
+		},
+		{
+			name:   "timestamp keeps its colons",
+			values: []string{"created_at:gte:2025-01-01T00:00:00Z"},
+			want: []domain.UserFilter{
+				{Field: "created_at", Op: "gte", Value: "2025-01-01T00:00:00Z", Time: since},
+			},
+		},
+		{
+			name:   "repeated parameters",
+			values: []string{"name:prefix:jo", "email:ieq:john@example.com"},
+			want: []domain.UserFilter{
+				{Field: "name", Op: "prefix", Value: "jo"},
+				{Field: "email", Op: "ieq", Value: "john@example.com"},
+			},
+		},
+		{
+			name:    "missing value",
+			values:  []string{"name:eq"},
+			wantErr: `invalid filter clause "name:eq": expected field:op:value`,
+		},
+		{
+			name:    "unknown field",
+			values:  []string{"password:eq:x"},
+			wantErr: `invalid filter clause "password:eq:x": field "password" is not filterable`,
+		},
+		{
+			name:    "unsupported operator",
+			values:  []string{"created_at:contains:2025"},
+			wantErr: `invalid filter clause "created_at:contains:2025"`,
+		},
+		{
+			name:    "bad timestamp",
+			values:  []string{"name:eq:john", "created_at:gt:yesterday"},
+			wantErr: `invalid filter clause "created_at:gt:yesterday"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseUserFilters(tt.values)
+			if tt.wantErr != "" {
+				if !errors.HasCode(err, constants.InvalidParameter) {
+					t.Fatalf("ParseUserFilters() error = %v, want %s", err, constants.InvalidParameter)
+				}
+				if msg := errors.PublicMessage(err); !strings.Contains(msg, tt.wantErr) {
+					t.Errorf("public message = %q, want it to contain %q", msg, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseUserFilters() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseUserFilters() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseUserSort(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []domain.UserSort
+		wantErr bool
+	}{
+		{name: "empty"},
+		{
+			name:  "ascending",
+			value: "name",
+			want:  []domain.UserSort{{Field: "name"}},
+		},
+		{
+			name:  "mixed directions with spaces",
+			value: "-created_at, name,",
+			want:  []domain.UserSort{{Field: "created_at", Desc: true}, {Field: "name"}},
+		},
+		{name: "unknown field", value: "-password", wantErr: true},
+		{name: "bare minus", value: "-", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseUserSort(tt.value)
+			if tt.wantErr {
+				if !errors.HasCode(err, constants.InvalidParameter) {
+					t.Errorf("ParseUserSort() error = %v, want %s", err, constants.InvalidParameter)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseUserSort() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseUserSort() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+	"github.com/wonjinsin/go-boilerplate/pkg/utils"
+)
+
+// Validation rule names reported in field violations.
+const (
+	RuleRequired  = "required"
+	RuleMinLength = "min_length"
+	RuleMaxLength = "max_length"
+	RuleEmail     = "email"
+	RuleMinItems  = "min_items"
+	RuleFuture    = "future"
+	RuleNotNull   = "not_null"
+)
+
+// validation collects every field violation of a request payload.
+type validation struct {
+	violations []errors.FieldViolation
+}
+
+// add records a violation of rule by field.
+func (v *validation) add(field, rule, message string) {
+	v.violations = append(v.violations, errors.FieldViolation{Field: field, Rule: rule, Message: message})
+}
+
+// required checks that value is not blank and reports whether it is present.
+func (v *validation) required(field, value string) bool {
+	if utils.IsEmptyOrWhitespace(value) {
+		v.add(field, RuleRequired, "is required")
+		return false
+	}
+	return true
+}
+
+// length checks that value, without surrounding whitespace, has between minLen and
+// maxLen bytes, the unit the domain limits are expressed in.
+func (v *validation) length(field, value string, minLen, maxLen int) {
+	n := len(strings.TrimSpace(value))
+	switch {
+	case n < minLen:
+		v.add(field, RuleMinLength, fmt.Sprintf("must be at least %d bytes", minLen))
+	case n > maxLen:
+		v.add(field, RuleMaxLength, fmt.Sprintf("must be at most %d bytes", maxLen))
+	}
+}
+
+// email checks that value is a well-formed email address.
+func (v *validation) email(field, value string) {
+	if !utils.IsValidEmail(value) {
+		v.add(field, RuleEmail, "must be a valid email address")
+	}
+}
+
+// minItems checks that items has at least n entries.
+func (v *validation) minItems(field string, items []string, n int) {
+	if len(items) < n {
+		v.add(field, RuleMinItems, fmt.Sprintf("must have at least %d item(s)", n))
+	}
+}
+
+// future checks that t, when set, is in the future.
+func (v *validation) future(field string, t *time.Time) {
+	if t != nil && !t.After(time.Now()) {
+		v.add(field, RuleFuture, "must be in the future")
+	}
+}
+
+// err returns the collected violations as a single error, or nil if there are none.
+func (v *validation) err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return errors.NewValidation(v.violations)
+}
@@ -0,0 +1,62 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	pkgConstants "github.com/wonjinsin/go-boilerplate/pkg/constants"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+)
+
+// setUserETag sets the strong entity tag of u, its quoted version.
+func setUserETag(w http.ResponseWriter, u *domain.User) {
+	w.Header().Set(pkgConstants.HeaderETag, strconv.Quote(strconv.Itoa(u.Version)))
+}
+
+// ifMatchVersion returns the user version required by the If-Match header, zero for
+// "*". The header is mandatory, so updates and deletes cannot override changes they have
+// not seen.
+func ifMatchVersion(r *http.Request) (int, error) {
+	tag := strings.TrimSpace(r.Header.Get(pkgConstants.HeaderIfMatch))
+	switch {
+	case tag == "":
+		return 0, errors.New(constants.PreconditionReq, "If-Match header is required", nil)
+	case tag == "*":
+		return 0, nil
+	case strings.HasPrefix(tag, "W/"):
+		// Weak tags never match under the strong comparison If-Match uses.
+		return 0, errors.New(constants.PreconditionFail, "user has been modified", nil)
+	}
+
+	value, err := strconv.Unquote(tag)
+	if err != nil || !strings.HasPrefix(tag, `"`) || strings.Contains(value, `"`) {
+		return 0, errors.New(constants.InvalidParameter, "If-Match must be a single entity tag or *", err)
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil || version <= 0 {
+		// Not a tag this server issued, so it cannot match.
+		return 0, errors.New(constants.PreconditionFail, "user has been modified", nil)
+	}
+	return version, nil
+}
+
+// notModified reports whether the If-None-Match header matches the entity tag set on w,
+// using the weak comparison: "W/" prefixes are ignored.
+func notModified(w http.ResponseWriter, r *http.Request) bool {
+	etag := w.Header().Get(pkgConstants.HeaderETag)
+	header := r.Header.Get(pkgConstants.HeaderIfNoneMatch)
+	if etag == "" || header == "" {
+		return false
+	}
+
+	for tag := range strings.SplitSeq(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	pkgConstants "github.com/wonjinsin/go-boilerplate/pkg/constants"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+)
+
+func TestIfMatchVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch string
+		want    int
+		// wantStatus is the HTTP status of the returned error; zero means success.
+		wantStatus int
+	}{
+		{name: "missing", wantStatus: http.StatusPreconditionRequired},
+		{name: "blank", ifMatch: "  ", wantStatus: http.StatusPreconditionRequired},
+		{name: "any", ifMatch: "*", want: 0},
+		{name: "strong tag", ifMatch: `"3"`, want: 3},
+		{name: "surrounding spaces", ifMatch: ` "3" `, want: 3},
+		{name: "weak tag", ifMatch: `W/"3"`, wantStatus: http.StatusPreconditionFailed},
+		{name: "foreign tag", ifMatch: `"abc"`, wantStatus: http.StatusPreconditionFailed},
+		{name: "zero version", ifMatch: `"0"`, wantStatus: http.StatusPreconditionFailed},
+		{name: "negative version", ifMatch: `"-1"`, wantStatus: http.StatusPreconditionFailed},
+		{name: "unquoted", ifMatch: "3", wantStatus: http.StatusBadRequest},
+		{name: "tag list", ifMatch: `"3", "4"`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "/users/1", nil)
+			if tt.ifMatch != "" {
+				r.Header.Set(pkgConstants.HeaderIfMatch, tt.ifMatch)
+			}
+
+			got, err := ifMatchVersion(r)
+			if tt.wantStatus != 0 {
+				mapping, _ := errors.Lookup(errors.GetCode(err))
+				if mapping.Status != tt.wantStatus {
+					t.Errorf("ifMatchVersion() error = %v (status %d), want status %d", err, mapping.Status, tt.wantStatus)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ifMatchVersion() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ifMatchVersion() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		noETag      bool
+		want        bool
+	}{
+		{name: "no header"},
+		{name: "matching tag", ifNoneMatch: `"3"`, want: true},
+		{name: "weak matching tag", ifNoneMatch: `W/"3"`, want: true},
+		{name: "tag in list", ifNoneMatch: `"1", W/"3"`, want: true},
+		{name: "any", ifNoneMatch: "*", want: true},
+		{name: "stale tag", ifNoneMatch: `"2"`},
+		{name: "no entity tag", ifNoneMatch: "*", noETag: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if !tt.noETag {
+				setUserETag(w, &domain.User{Version: 3})
+			}
+			r := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+			if tt.ifNoneMatch != "" {
+				r.Header.Set(pkgConstants.HeaderIfNoneMatch, tt.ifNoneMatch)
+			}
+
+			if got := notModified(w, r); got != tt.want {
+				t.Errorf("notModified() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/wonjinsin/go-boilerplate/pkg/utils"
+)
+
+// HandlerFunc is an HTTP handler that returns its error instead of writing it, so every
+// error is mapped to a status and logged in one place.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ServeHTTP calls h and renders a returned error with utils.WriteError.
+func (h HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := h(w, r); err != nil {
+		utils.WriteError(w, r, err)
+	}
+}
+
+// handle adapts h for router registration.
+func handle(h HandlerFunc) http.HandlerFunc {
+	return h.ServeHTTP
+}
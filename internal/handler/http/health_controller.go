@@ -1,20 +1,52 @@
 package http
 
 import (
+	"context"
 	"net/http"
 
+	"github.com/wonjinsin/go-boilerplate/internal/handler/http/dto"
+	"github.com/wonjinsin/go-boilerplate/pkg/health"
+	"github.com/wonjinsin/go-boilerplate/pkg/logger"
 	"github.com/wonjinsin/go-boilerplate/pkg/utils"
 )
 
+// ReadinessChecker reports whether the service can serve traffic.
+type ReadinessChecker interface {
+	Check(ctx context.Context) health.Report
+}
+
 // HealthController handles health check endpoints.
-type HealthController struct{}
+type HealthController struct {
+	readiness ReadinessChecker
+}
 
 // NewHealthController creates a new health controller.
-func NewHealthController() *HealthController {
-	return &HealthController{}
+func NewHealthController(readiness ReadinessChecker) *HealthController {
+	return &HealthController{readiness: readiness}
 }
 
-// Check handles health check requests.
-func (c *HealthController) Check(w http.ResponseWriter, r *http.Request) {
+// Live handles liveness probes. It only reports that the process is serving requests,
+// so a dependency outage does not get the process restarted.
+func (c *HealthController) Live(w http.ResponseWriter, r *http.Request) {
 	utils.WriteStandardJSON(w, r, http.StatusOK, nil)
 }
+
+// Ready handles readiness probes. It returns 503 while a dependency is down or the
+// server is shutting down.
+func (c *HealthController) Ready(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	report := c.readiness.Check(ctx)
+	for name, result := range report.Components {
+		if result.Err != nil {
+			logger.LogWarn(ctx, "readiness check "+name+" failed: "+result.Err.Error())
+		}
+	}
+
+	httpStatus := http.StatusOK
+	if !report.Ready() {
+		httpStatus = http.StatusServiceUnavailable
+	}
+
+	utils.WriteStandardJSON(w, r, httpStatus, dto.ToHealthResponse(report))
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	pkgConstants "github.com/wonjinsin/go-boilerplate/pkg/constants"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+	"github.com/wonjinsin/go-boilerplate/pkg/logger"
+)
+
+// APIKeyAuthenticator resolves a presented API key.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (*domain.APIKey, error)
+}
+
+// APIKeyAuth returns a middleware that authenticates "Authorization: ApiKey <key>" or
+// "X-API-Key: <key>" credentials. Requests without an API key pass through untouched,
+// so it is placed before Authenticate to accept either credential. The key owner is
+// stored under constants.ContextKeyUserID, the key ID under constants.ContextKeyAPIKeyID
+// and the key scopes under constants.ContextKeyAPIKeyScopes.
+func APIKeyAuth(authenticator APIKeyAuthenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			key, ok := apiKey(r)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			k, err := authenticator.AuthenticateAPIKey(ctx, key)
+			if err != nil {
+				if errors.HasCode(err, constants.Unauthorized) {
+					writeUnauthorized(w, r, errors.New(constants.Unauthorized, "invalid api key", err))
+					return
+				}
+				writeLookupError(w, r, err, "failed to authenticate api key")
+				return
+			}
+
+			logger.LogInfo(ctx, "authenticated with api key "+k.Prefix)
+			ctx = context.WithValue(ctx, constants.ContextKeyUserID, strconv.Itoa(k.OwnerID))
+			ctx = context.WithValue(ctx, constants.ContextKeyAPIKeyID, strconv.Itoa(k.ID))
+			ctx = context.WithValue(ctx, constants.ContextKeyAPIKeyScopes, k.Scopes)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// apiKey extracts the key from the Authorization header or the X-API-Key header.
+func apiKey(r *http.Request) (string, bool) {
+	scheme, key, ok := strings.Cut(r.Header.Get(pkgConstants.HeaderAuthorization), " ")
+	if !ok || !strings.EqualFold(scheme, pkgConstants.AuthSchemeAPIKey) {
+		key = r.Header.Get(pkgConstants.HeaderAPIKey)
+	}
+	key = strings.TrimSpace(key)
+	return key, key != ""
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	pkgConstants "github.com/wonjinsin/go-boilerplate/pkg/constants"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+	"github.com/wonjinsin/go-boilerplate/pkg/jwtauth"
+	"github.com/wonjinsin/go-boilerplate/pkg/utils"
+)
+
+// TokenVerifier validates a bearer token and returns its claims.
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*jwtauth.Claims, error)
+}
+
+// Authenticate returns a middleware that requires a valid bearer JWT.
+// The token subject is stored in the request context under constants.ContextKeyUserID.
+// Requests already authenticated by an earlier middleware (APIKeyAuth) pass through.
+func Authenticate(verifier TokenVerifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			if GetUserID(ctx) != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token, ok := bearerToken(r)
+			if !ok {
+				writeUnauthorized(w, r, errors.New(constants.Unauthorized, "missing bearer token or api key", nil))
+				return
+			}
+
+			claims, err := verifier.Verify(ctx, token)
+			if err != nil {
+				writeUnauthorized(w, r, errors.New(constants.Unauthorized, "invalid bearer token", err))
+				return
+			}
+			if claims.Subject == "" {
+				writeUnauthorized(w, r, errors.New(constants.Unauthorized, "invalid bearer token", nil))
+				return
+			}
+
+			ctx = context.WithValue(ctx, constants.ContextKeyUserID, claims.Subject)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetUserID extracts the authenticated user ID from context.
+func GetUserID(ctx context.Context) string {
+	if userID, ok := ctx.Value(constants.ContextKeyUserID).(string); ok {
+		return userID
+	}
+	return ""
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>" header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get(pkgConstants.HeaderAuthorization), " ")
+	if !ok || !strings.EqualFold(scheme, pkgConstants.AuthSchemeBearer) {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// writeUnauthorized writes the Unauthorized error err, challenging for both accepted
+// schemes.
+func writeUnauthorized(w http.ResponseWriter, r *http.Request, err error) {
+	w.Header().Set(pkgConstants.HeaderWWWAuth, pkgConstants.AuthSchemeBearer)
+	w.Header().Add(pkgConstants.HeaderWWWAuth, pkgConstants.AuthSchemeAPIKey)
+	utils.WriteError(w, r, err)
+}
+
+// writeLookupError writes the response for a failed credential or permission lookup.
+// Canceled and timed out lookups keep their codes; anything else is an internal error.
+func writeLookupError(w http.ResponseWriter, r *http.Request, err error, msg string) {
+	code := errors.GetCode(err)
+	if code != constants.Canceled && code != constants.Timeout {
+		code = constants.InternalError
+	}
+	utils.WriteError(w, r, errors.New(code, msg, err))
+}
@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	pkgConstants "github.com/wonjinsin/go-boilerplate/pkg/constants"
+	pkgErrors "github.com/wonjinsin/go-boilerplate/pkg/errors"
+	"github.com/wonjinsin/go-boilerplate/pkg/jwtauth"
+)
+
+// stubVerifier accepts only the token "valid", as user 1.
+type stubVerifier struct{}
+
+func (stubVerifier) Verify(_ context.Context, token string) (*jwtauth.Claims, error) {
+	if token != "valid" {
+		return nil, errors.New("bad signature")
+	}
+	return &jwtauth.Claims{Subject: "1"}, nil
+}
+
+// stubPermissions returns fixed permissions, or Unauthorized without a caller.
+type stubPermissions domain.Permissions
+
+func (s stubPermissions) Permissions(ctx context.Context) (domain.Permissions, error) {
+	if GetUserID(ctx) == "" {
+		return nil, pkgErrors.New(constants.Unauthorized, "unauthenticated caller", nil)
+	}
+	return domain.Permissions(s), nil
+}
+
+func TestAuthErrorsUseRegistry(t *testing.T) {
+	perms := stubPermissions{domain.PermissionUsersRead}
+
+	tests := []struct {
+		name       string
+		handler    http.Handler
+		auth       string
+		wantStatus int
+		wantCode   string
+		wantChall  bool
+	}{
+		{
+			name:       "missing token",
+			handler:    Authenticate(stubVerifier{})(okHandler()),
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   string(constants.Unauthorized),
+			wantChall:  true,
+		},
+		{
+			name:       "invalid token",
+			handler:    Authenticate(stubVerifier{})(okHandler()),
+			auth:       "Bearer forged",
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   string(constants.Unauthorized),
+			wantChall:  true,
+		},
+		{
+			name:       "permission without caller",
+			handler:    RequirePermission(perms, domain.PermissionUsersRead)(okHandler()),
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   string(constants.Unauthorized),
+			wantChall:  true,
+		},
+		{
+			name: "missing permission",
+			handler: Authenticate(stubVerifier{})(
+				RequirePermission(perms, domain.PermissionUsersWrite)(okHandler()),
+			),
+			auth:       "Bearer valid",
+			wantStatus: http.StatusForbidden,
+			wantCode:   string(constants.Forbidden),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/users", nil)
+			ctx := context.WithValue(r.Context(), pkgConstants.ContextKeyErrorFormat, pkgConstants.ErrorFormatProblem)
+			r = r.WithContext(ctx)
+			if tt.auth != "" {
+				r.Header.Set(pkgConstants.HeaderAuthorization, tt.auth)
+			}
+			w := httptest.NewRecorder()
+			tt.handler.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			var problem struct {
+				Title string `json:"title"`
+				Code  string `json:"code"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+				t.Fatalf("failed to decode problem: %v", err)
+			}
+			if problem.Code != tt.wantCode || problem.Title != http.StatusText(tt.wantStatus) {
+				t.Errorf("problem = %+v, want code %s and title %q", problem, tt.wantCode, http.StatusText(tt.wantStatus))
+			}
+			if got := w.Header().Values(pkgConstants.HeaderWWWAuth); (len(got) > 0) != tt.wantChall {
+				t.Errorf("%s = %v, want challenge %v", pkgConstants.HeaderWWWAuth, got, tt.wantChall)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/wonjinsin/go-boilerplate/pkg/constants"
+)
+
+// BodyLimit returns a middleware that caps request bodies at maxBytes
+// (constants.DefaultMaxBodyBytes when not positive). Reading past the cap fails, which
+// utils.ParseJSONBody reports as 413.
+func BodyLimit(maxBytes int64) func(http.Handler) http.Handler {
+	if maxBytes <= 0 {
+		maxBytes = constants.DefaultMaxBodyBytes
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
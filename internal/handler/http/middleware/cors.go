@@ -21,15 +21,29 @@ type CORSConfig struct {
 func DefaultCORSConfig() CORSConfig {
 	return CORSConfig{
 		AllowedOrigins: []string{"*"},
-		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowedHeaders: []string{
 			constants.HeaderContentType,
 			constants.HeaderAuthorization,
 			constants.HeaderAccept,
+			constants.HeaderAPIKey,
+			constants.HeaderIdempotencyKey,
+			constants.HeaderIfMatch,
+			constants.HeaderIfNoneMatch,
 		},
-		ExposedHeaders: []string{},
-		MaxAge:         86400, // 24 hours.
-		Credentials:    false,
+		ExposedHeaders: []string{
+			constants.HeaderRequestID,
+			constants.HeaderLocation,
+			constants.HeaderETag,
+			constants.HeaderIdempotentReplayed,
+			constants.HeaderRateLimitLimit,
+			constants.HeaderRateLimitRemaining,
+			constants.HeaderRateLimitReset,
+			constants.HeaderRateLimitPolicy,
+			constants.HeaderRetryAfter,
+		},
+		MaxAge:      86400, // 24 hours.
+		Credentials: false,
 	}
 }
 
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/wonjinsin/go-boilerplate/pkg/constants"
+)
+
+// ErrorFormat returns a middleware that selects how error responses are rendered.
+// Requests accepting application/problem+json get RFC 7807 problem details; the rest
+// get defaultFormat (the standard envelope when empty).
+func ErrorFormat(defaultFormat string) func(http.Handler) http.Handler {
+	if defaultFormat == "" {
+		defaultFormat = constants.ErrorFormatStandard
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			format := defaultFormat
+			if acceptsProblemJSON(r.Header.Get(constants.HeaderAccept)) {
+				format = constants.ErrorFormatProblem
+			}
+
+			ctx := context.WithValue(r.Context(), constants.ContextKeyErrorFormat, format)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// acceptsProblemJSON reports whether an Accept header lists application/problem+json.
+func acceptsProblemJSON(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err == nil && mediaType == constants.ContentTypeProblemJSON {
+			return true
+		}
+	}
+	return false
+}
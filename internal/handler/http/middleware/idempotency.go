@@ -0,0 +1,244 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	pkgConstants "github.com/wonjinsin/go-boilerplate/pkg/constants"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+	"github.com/wonjinsin/go-boilerplate/pkg/logger"
+	"github.com/wonjinsin/go-boilerplate/pkg/utils"
+)
+
+// IdempotencyStore claims idempotency keys and stores the responses replayed to retries.
+type IdempotencyStore interface {
+	Begin(ctx context.Context, scope, key, fingerprint string) (*domain.IdempotencyRecord, error)
+	Complete(ctx context.Context, rec *domain.IdempotencyRecord) error
+	RenewLease(ctx context.Context, rec *domain.IdempotencyRecord) error
+	Release(ctx context.Context, rec *domain.IdempotencyRecord) error
+}
+
+// Idempotency returns a middleware that makes POST requests carrying an Idempotency-Key
+// header safe to retry. The first request with a key runs and its response is stored;
+// repeats with the same method, path and body get the stored response, marked with
+// Idempotent-Replayed. Reusing a key for a different request is rejected with 422, and
+// a repeat arriving while the first is still running with 409. The running request keeps
+// its key leased, so a repeat takes it over only once the lease lapsed because that
+// request died. Replays carry the TrID of the repeat. Server errors are not stored, so
+// they can be retried. Keys are scoped to the caller, so it is placed after
+// authentication. Response bodies are stored as written, so routes whose responses carry
+// secrets, such as API key issuance, must not use it.
+func Idempotency(store IdempotencyStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(pkgConstants.HeaderIdempotencyKey)
+			if r.Method != http.MethodPost || key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ctx := r.Context()
+
+			if len(key) > pkgConstants.MaxIdempotencyKeyLength {
+				utils.WriteError(w, r, errors.New(constants.InvalidParameter, fmt.Sprintf(
+					"idempotency key must be at most %d characters", pkgConstants.MaxIdempotencyKeyLength), nil))
+				return
+			}
+
+			// The body is part of the fingerprint; buffer it for the handler.
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				utils.WriteError(w, r, readBodyError(err))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			scope, _ := ctx.Value(constants.ContextKeyUserID).(string)
+			fingerprint := utils.HashSHA256(r.Method + " " + r.URL.RequestURI() + "\n" + string(body))
+			rec, err := store.Begin(ctx, scope, key, fingerprint)
+			if err != nil {
+				utils.WriteError(w, r, err)
+				return
+			}
+			if rec.Status == domain.IdempotencyCompleted {
+				logger.LogInfo(ctx, "replaying idempotent response")
+				replay(w, r, rec)
+				return
+			}
+
+			// Storing must not depend on the client still waiting.
+			storeCtx := context.WithoutCancel(ctx)
+			stored := false
+			defer func() {
+				// Release the key if the handler panicked or failed.
+				if !stored {
+					if err := store.Release(storeCtx, rec); err != nil {
+						logger.LogError(ctx, "failed to release idempotency key", err)
+					}
+				}
+			}()
+			stopRenewing := keepLeased(storeCtx, store, rec)
+			defer stopRenewing()
+
+			var buf bytes.Buffer
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(&buf)
+			next.ServeHTTP(ww, r)
+			stopRenewing()
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			if status >= http.StatusInternalServerError {
+				return
+			}
+
+			rec.Complete(status, replayedHeaders(ww.Header()), buf.Bytes())
+			if err := store.Complete(storeCtx, rec); err != nil {
+				logger.LogError(ctx, "failed to store idempotent response", err)
+				return
+			}
+			stored = true
+		})
+	}
+}
+
+// minLeaseRenewInterval bounds how often the lease of a running request is renewed.
+const minLeaseRenewInterval = 100 * time.Millisecond
+
+// keepLeased renews the lease of rec at half its length until the returned function is
+// called, which also waits for a renewal in flight, so rec is not shared afterwards.
+func keepLeased(ctx context.Context, store IdempotencyStore, rec *domain.IdempotencyRecord) func() {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	interval := max(time.Until(rec.LockedUntil)/2, minLeaseRenewInterval)
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			err := store.RenewLease(ctx, rec)
+			switch {
+			case err == nil || ctx.Err() != nil:
+			case errors.HasCode(err, constants.NotFound):
+				// Taken over after earlier renewals failed; nothing is left to renew.
+				logger.LogError(ctx, "lost idempotency key while its request runs", err)
+				return
+			default:
+				logger.LogError(ctx, "failed to renew idempotency key", err)
+			}
+		}
+	}()
+
+	return sync.OnceFunc(func() {
+		cancel()
+		<-done
+	})
+}
+
+// idempotentHeaders are the response headers stored and replayed with the body. Headers
+// describing the created resource are kept; per-request ones such as the TrID are not.
+var idempotentHeaders = []string{
+	pkgConstants.HeaderContentType,
+	pkgConstants.HeaderLocation,
+	pkgConstants.HeaderETag,
+}
+
+// replayedHeaders returns the idempotentHeaders set in h.
+func replayedHeaders(h http.Header) map[string]string {
+	headers := make(map[string]string, len(idempotentHeaders))
+	for _, name := range idempotentHeaders {
+		if v := h.Get(name); v != "" {
+			headers[name] = v
+		}
+	}
+	return headers
+}
+
+// replay writes the stored response of rec, with the TrID of r in place of the original.
+func replay(w http.ResponseWriter, r *http.Request, rec *domain.IdempotencyRecord) {
+	body := rec.ResponseBody
+	if trID, _ := r.Context().Value(pkgConstants.ContextKeyTrID).(string); trID != "" {
+		body = replaceTrID(body, trID)
+	}
+
+	for name, v := range rec.ResponseHeaders {
+		w.Header().Set(name, v)
+	}
+	w.Header().Set(pkgConstants.HeaderIdempotentReplayed, "true")
+	w.WriteHeader(rec.ResponseStatus)
+	_, _ = w.Write(body)
+}
+
+// replaceTrID returns body with the value of its top-level "trid" field set to trID,
+// keeping the order of the other fields. Bodies that are not JSON objects with a TrID
+// are returned unchanged.
+func replaceTrID(body []byte, trID string) []byte {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return body
+	}
+
+	var (
+		buf   bytes.Buffer
+		found bool
+	)
+	buf.WriteByte('{')
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return body
+		}
+		name, _ := tok.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return body
+		}
+		if name == "trid" {
+			found = true
+			value, _ = json.Marshal(trID)
+		}
+
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	if _, err := dec.Token(); err != nil || !found {
+		return body
+	}
+	buf.WriteByte('}')
+	// Keep what follows the object, such as the newline written by the JSON encoder.
+	buf.Write(body[dec.InputOffset():])
+	return buf.Bytes()
+}
+
+// readBodyError converts a failure to read the request body into a client error.
+func readBodyError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if stderrors.As(err, &maxBytesErr) {
+		return errors.New(constants.PayloadTooLarge,
+			fmt.Sprintf("request body exceeds %d bytes", maxBytesErr.Limit), err)
+	}
+	return errors.New(constants.InvalidParameter, "failed to read request body", err)
+}
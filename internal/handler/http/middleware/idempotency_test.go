@@ -0,0 +1,178 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	pkgConstants "github.com/wonjinsin/go-boilerplate/pkg/constants"
+)
+
+// fakeIdempotencyStore hands out rec and records how the middleware uses it.
+type fakeIdempotencyStore struct {
+	mu        sync.Mutex
+	rec       *domain.IdempotencyRecord
+	lease     time.Duration
+	renewals  int
+	completed bool
+	released  bool
+}
+
+func (s *fakeIdempotencyStore) Begin(_ context.Context, _, _, _ string) (*domain.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rec.Status == domain.IdempotencyInProgress {
+		s.rec.RenewLease(s.lease, time.Now())
+	}
+	return s.rec, nil
+}
+
+func (s *fakeIdempotencyStore) Complete(_ context.Context, _ *domain.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.completed = true
+	return nil
+}
+
+func (s *fakeIdempotencyStore) RenewLease(_ context.Context, rec *domain.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec.RenewLease(s.lease, time.Now())
+	s.renewals++
+	return nil
+}
+
+func (s *fakeIdempotencyStore) Release(_ context.Context, _ *domain.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.released = true
+	return nil
+}
+
+func (s *fakeIdempotencyStore) snapshot() (renewals int, completed, released bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.renewals, s.completed, s.released
+}
+
+func TestIdempotencyRenewsLeaseWhileRunning(t *testing.T) {
+	const lease = 200 * time.Millisecond
+
+	tests := []struct {
+		name          string
+		run           time.Duration
+		status        int
+		wantRenewals  bool
+		wantCompleted bool
+		wantReleased  bool
+	}{
+		{name: "fast request", status: http.StatusCreated, wantCompleted: true},
+		{
+			name:          "request outliving its lease",
+			run:           3 * lease,
+			status:        http.StatusCreated,
+			wantRenewals:  true,
+			wantCompleted: true,
+		},
+		{
+			name:         "failed request outliving its lease",
+			run:          3 * lease,
+			status:       http.StatusInternalServerError,
+			wantRenewals: true,
+			wantReleased: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeIdempotencyStore{
+				rec:   &domain.IdempotencyRecord{ID: 1, Status: domain.IdempotencyInProgress},
+				lease: lease,
+			}
+			handler := Idempotency(store)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				time.Sleep(tt.run)
+				w.WriteHeader(tt.status)
+			}))
+
+			r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{}`))
+			r.Header.Set(pkgConstants.HeaderIdempotencyKey, "k")
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+
+			renewals, completed, released := store.snapshot()
+			if (renewals > 0) != tt.wantRenewals {
+				t.Errorf("renewals = %d, want renewals %v", renewals, tt.wantRenewals)
+			}
+			if completed != tt.wantCompleted || released != tt.wantReleased {
+				t.Errorf("completed, released = %v, %v, want %v, %v",
+					completed, released, tt.wantCompleted, tt.wantReleased)
+			}
+
+			// Renewals stop with the request.
+			time.Sleep(lease)
+			if after, _, _ := store.snapshot(); after != renewals {
+				t.Errorf("renewals after the request = %d, want %d", after, renewals)
+			}
+		})
+	}
+}
+
+func TestIdempotencyReplaysWithCurrentTrID(t *testing.T) {
+	store := &fakeIdempotencyStore{rec: &domain.IdempotencyRecord{
+		ID:              1,
+		Status:          domain.IdempotencyCompleted,
+		ResponseStatus:  http.StatusCreated,
+		ResponseHeaders: map[string]string{pkgConstants.HeaderContentType: "application/json"},
+		ResponseBody:    []byte(`{"trid":"first","code":"0201","result":{"id":1}}` + "\n"),
+	}}
+	handler := Idempotency(store)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("handler ran for a replayed request")
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{}`))
+	r.Header.Set(pkgConstants.HeaderIdempotencyKey, "k")
+	r = r.WithContext(context.WithValue(r.Context(), pkgConstants.ContextKeyTrID, "second"))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	want := `{"trid":"second","code":"0201","result":{"id":1}}` + "\n"
+	if w.Code != http.StatusCreated || w.Body.String() != want {
+		t.Errorf("replay = %d %q, want %d %q", w.Code, w.Body, http.StatusCreated, want)
+	}
+}
+
+func TestReplaceTrID(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "standard response",
+			body: `{"trid":"old","code":"0201","result":{"trid":"nested"}}` + "\n",
+			want: `{"trid":"new","code":"0201","result":{"trid":"nested"}}` + "\n",
+		},
+		{
+			name: "problem details",
+			body: `{"type":"about:blank","title":"Conflict","status":409,"trid":"old","code":"0409"}`,
+			want: `{"type":"about:blank","title":"Conflict","status":409,"trid":"new","code":"0409"}`,
+		},
+		{name: "no trid", body: `{"code":"0201"}`, want: `{"code":"0201"}`},
+		{name: "array", body: `[{"trid":"old"}]`, want: `[{"trid":"old"}]`},
+		{name: "not json", body: "created", want: "created"},
+		{name: "truncated", body: `{"trid":"old","code":`, want: `{"trid":"old","code":`},
+		{name: "empty", body: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(replaceTrID([]byte(tt.body), "new")); got != tt.want {
+				t.Errorf("replaceTrID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
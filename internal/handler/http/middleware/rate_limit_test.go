@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pkgConstants "github.com/wonjinsin/go-boilerplate/pkg/constants"
+	"github.com/wonjinsin/go-boilerplate/pkg/ratelimit"
+)
+
+// stubLimiter returns a fixed result and records the keys it was asked about.
+type stubLimiter struct {
+	res  ratelimit.Result
+	err  error
+	keys []string
+}
+
+func (s *stubLimiter) Allow(_ context.Context, key string, _ ratelimit.Limit) (ratelimit.Result, error) {
+	s.keys = append(s.keys, key)
+	return s.res, s.err
+}
+
+func TestRateLimit(t *testing.T) {
+	limit := ratelimit.Limit{Requests: 10, Window: time.Minute}
+
+	tests := []struct {
+		name       string
+		res        ratelimit.Result
+		err        error
+		wantStatus int
+		// wantHeaders are expected values; "" means the header is absent.
+		wantHeaders map[string]string
+	}{
+		{
+			name:       "allowed",
+			res:        ratelimit.Result{Allowed: true, Limit: 10, Remaining: 7, Reset: 18 * time.Second},
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				pkgConstants.HeaderRateLimitLimit:     "10",
+				pkgConstants.HeaderRateLimitRemaining: "7",
+				pkgConstants.HeaderRateLimitReset:     "18",
+				pkgConstants.HeaderRateLimitPolicy:    "10;w=60",
+				pkgConstants.HeaderRetryAfter:         "",
+			},
+		},
+		{
+			name:       "reset rounds up",
+			res:        ratelimit.Result{Allowed: true, Limit: 10, Remaining: 9, Reset: 1500 * time.Millisecond},
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				pkgConstants.HeaderRateLimitReset: "2",
+			},
+		},
+		{
+			name: "denied",
+			res: ratelimit.Result{
+				Limit: 10, Remaining: 0, Reset: 45 * time.Second, RetryAfter: 2100 * time.Millisecond,
+			},
+			wantStatus: http.StatusTooManyRequests,
+			wantHeaders: map[string]string{
+				pkgConstants.HeaderRateLimitRemaining: "0",
+				pkgConstants.HeaderRateLimitReset:     "45",
+				pkgConstants.HeaderRetryAfter:         "3",
+			},
+		},
+		{
+			name:       "retry after is at least a second",
+			res:        ratelimit.Result{Limit: 10, RetryAfter: 0},
+			wantStatus: http.StatusTooManyRequests,
+			wantHeaders: map[string]string{
+				pkgConstants.HeaderRetryAfter: "1",
+			},
+		},
+		{
+			name:       "limiter failure lets requests through",
+			err:        errors.New("store down"),
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				pkgConstants.HeaderRateLimitLimit: "",
+				pkgConstants.HeaderRetryAfter:     "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := &stubLimiter{res: tt.res, err: tt.err}
+			handler := RateLimit(limiter, "api", limit, ClientIPKey)(okHandler())
+
+			r := httptest.NewRequest(http.MethodGet, "/users", nil)
+			r.RemoteAddr = "192.0.2.1:1234"
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			for name, want := range tt.wantHeaders {
+				if got := w.Header().Get(name); got != want {
+					t.Errorf("%s = %q, want %q", name, got, want)
+				}
+			}
+			if len(limiter.keys) != 1 || limiter.keys[0] != "api:ip:192.0.2.1" {
+				t.Errorf("keys = %v, want [api:ip:192.0.2.1]", limiter.keys)
+			}
+		})
+	}
+}
+
+func TestRateLimitSkipsRequestsWithoutKey(t *testing.T) {
+	limiter := &stubLimiter{res: ratelimit.Result{Limit: 10}}
+	limit := ratelimit.Limit{Requests: 10, Window: time.Minute}
+	handler := RateLimit(limiter, "api", limit, CallerKey)(okHandler())
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if len(limiter.keys) != 0 {
+		t.Errorf("limiter called with %v, want no calls", limiter.keys)
+	}
+}
+
+func okHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+	"github.com/wonjinsin/go-boilerplate/pkg/utils"
+)
+
+// PermissionLoader resolves the permissions of the caller carried in the context.
+type PermissionLoader interface {
+	Permissions(ctx context.Context) (domain.Permissions, error)
+}
+
+// RequirePermission returns a middleware that rejects callers without the permission.
+// It must run after Authenticate. The resolved permissions are cached in the request
+// context under constants.ContextKeyPermissions so later checks do not reload them.
+func RequirePermission(loader PermissionLoader, permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			perms, err := loader.Permissions(ctx)
+			if err != nil {
+				if errors.HasCode(err, constants.Unauthorized) {
+					writeUnauthorized(w, r, errors.New(constants.Unauthorized, "authentication required", err))
+					return
+				}
+				writeLookupError(w, r, err, "failed to load permissions")
+				return
+			}
+
+			if !perms.Has(permission) {
+				utils.WriteError(w, r, errors.New(constants.Forbidden, "missing permission "+permission, nil))
+				return
+			}
+
+			ctx = context.WithValue(ctx, constants.ContextKeyPermissions, perms)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
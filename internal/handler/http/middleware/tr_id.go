@@ -5,19 +5,32 @@ import (
 	"crypto/rand"
 	"fmt"
 	"net/http"
+	"regexp"
 	"time"
 
 	"github.com/wonjinsin/go-boilerplate/pkg/constants"
 )
 
-// TrID returns a middleware that generates transaction IDs.
-// Format: YYYYMMDDHHMMSSmmm (date+time+milliseconds) + 5-digit random number.
+var trIDRegex = regexp.MustCompile(constants.TrIDPattern)
+
+// TrID returns a middleware that assigns each request a transaction ID.
+// A valid ID received in header (X-Request-ID when empty) is kept, so IDs from an upstream
+// gateway survive; otherwise a new one is generated. The ID is echoed in the same header.
+// Generated format: YYYYMMDDHHMMSSmmm (date+time+milliseconds) + 5-digit random number.
 // Example: 2025010101010199912345 (23 digits total).
-func TrID() func(http.Handler) http.Handler {
+func TrID(header string) func(http.Handler) http.Handler {
+	if header == "" {
+		header = constants.HeaderRequestID
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Generate TrID.
-			trID := GenerateTrID()
+			// Reuse the inbound ID or generate a new one.
+			trID := r.Header.Get(header)
+			if !isValidTrID(trID) {
+				trID = GenerateTrID()
+			}
+			w.Header().Set(header, trID)
 
 			// Add to context.
 			ctx := context.WithValue(r.Context(), constants.ContextKeyTrID, trID)
@@ -28,6 +41,11 @@ func TrID() func(http.Handler) http.Handler {
 	}
 }
 
+// isValidTrID reports whether an inbound ID is safe to log and echo.
+func isValidTrID(id string) bool {
+	return id != "" && len(id) <= constants.MaxTrIDLength && trIDRegex.MatchString(id)
+}
+
 // GenerateTrID generates a transaction ID.
 // Format: YYYYMMDDHHMMSSmmm + 5-digit random number.
 func GenerateTrID() string {
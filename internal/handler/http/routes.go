@@ -1,36 +1,127 @@
 package http
 
 import (
+	"net/http"
+	"slices"
+
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 
+	"github.com/wonjinsin/go-boilerplate/internal/config"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
 	custommiddleware "github.com/wonjinsin/go-boilerplate/internal/handler/http/middleware"
 	"github.com/wonjinsin/go-boilerplate/internal/usecase"
+	"github.com/wonjinsin/go-boilerplate/pkg/ratelimit"
 )
 
 // NewRouter creates and configures a new chi router.
-func NewRouter(userSvc usecase.UserService) *chi.Mux {
+func NewRouter(
+	cfg *config.Config,
+	userSvc usecase.UserService,
+	authSvc usecase.AuthService,
+	apiKeySvc usecase.APIKeyService,
+	auditSvc usecase.AuditService,
+	verifier custommiddleware.TokenVerifier,
+	permissions custommiddleware.PermissionLoader,
+	metrics *custommiddleware.HTTPMetrics,
+	readiness ReadinessChecker,
+	idempotency custommiddleware.IdempotencyStore,
+	limiter ratelimit.Limiter,
+) *chi.Mux {
 	r := chi.NewRouter()
 
+	// Browsers may read the TrID response header.
+	corsCfg := custommiddleware.DefaultCORSConfig()
+	corsCfg.AllowedOrigins = cfg.Server.CORSAllowedOrigins
+	corsCfg.Credentials = cfg.Server.CORSAllowCredentials
+	if !slices.Contains(corsCfg.ExposedHeaders, cfg.Server.RequestIDHeader) {
+		corsCfg.ExposedHeaders = append(corsCfg.ExposedHeaders, cfg.Server.RequestIDHeader)
+	}
+
 	// Middleware.
-	r.Use(custommiddleware.TrID())
-	r.Use(custommiddleware.CORS())
+	r.Use(custommiddleware.Tracing())
+	r.Use(custommiddleware.TrID(cfg.Server.RequestIDHeader))
+	r.Use(custommiddleware.ErrorFormat(cfg.Server.ErrorFormat))
+	r.Use(custommiddleware.Metrics(metrics))
+	r.Use(custommiddleware.CORS(corsCfg))
 	r.Use(middleware.RealIP)
+	r.Use(custommiddleware.BodyLimit(cfg.Server.MaxBodyBytes))
 	r.Use(custommiddleware.HTTPLogger())
 	r.Use(middleware.Recoverer)
 
 	// Controllers.
-	healthCtrl := NewHealthController()
-	userCtrl := NewUserController(userSvc)
+	healthCtrl := NewHealthController(readiness)
+	userCtrl := NewUserController(userSvc, []byte(cfg.Pagination.CursorSecret))
+	authCtrl := NewAuthController(authSvc)
+	apiKeyCtrl := NewAPIKeyController(apiKeySvc)
+	auditCtrl := NewAuditController(auditSvc)
+
+	// Rate limits. Auth routes are limited per client IP. Protected routes are limited per
+	// client IP before authentication, so bad credentials are limited too, and per caller
+	// after it.
+	apiLimit := ratelimit.Limit{Requests: cfg.RateLimit.Requests, Window: cfg.RateLimit.Window}
+	ipLimit := ratelimit.Limit{Requests: cfg.RateLimit.IPRequests, Window: cfg.RateLimit.IPWindow}
+	authLimit := ratelimit.Limit{Requests: cfg.RateLimit.AuthRequests, Window: cfg.RateLimit.AuthWindow}
+
+	// Protected routes accept an API key or a bearer token.
+	authenticate := []func(http.Handler) http.Handler{
+		custommiddleware.RateLimit(limiter, "ip", ipLimit, custommiddleware.ClientIPKey),
+		custommiddleware.APIKeyAuth(apiKeySvc),
+		custommiddleware.Authenticate(verifier),
+		custommiddleware.RateLimit(limiter, "api", apiLimit, custommiddleware.CallerKey),
+	}
 
 	// Routes.
-	r.Get("/healthz", healthCtrl.Check)
+	r.Get("/livez", healthCtrl.Live)
+	r.Get("/readyz", healthCtrl.Ready)
+	// Deprecated alias of /livez, kept for probes configured before the split.
+	r.Get("/healthz", healthCtrl.Live)
+
+	// Auth routes.
+	r.Route("/auth", func(r chi.Router) {
+		r.Use(custommiddleware.RateLimit(limiter, "auth", authLimit, custommiddleware.ClientIPKey))
+		r.Post("/login", handle(authCtrl.Login))
+		r.Post("/refresh", handle(authCtrl.Refresh))
+		r.Post("/logout", handle(authCtrl.Logout))
+	})
 
-	// User routes.
+	// User routes. UserService re-checks the same permissions, which also limits
+	// include_deleted listings to users:admin.
 	r.Route("/users", func(r chi.Router) {
-		r.Post("/", userCtrl.CreateUser)
-		r.Get("/", userCtrl.ListUsers)
-		r.Get("/{id}", userCtrl.GetUser)
+		r.Use(authenticate...)
+		// POST requests honor Idempotency-Key, scoped to the authenticated caller.
+		r.Use(custommiddleware.Idempotency(idempotency))
+
+		r.With(custommiddleware.RequirePermission(permissions, domain.PermissionUsersRead)).
+			Get("/", handle(userCtrl.ListUsers))
+		r.With(custommiddleware.RequirePermission(permissions, domain.PermissionUsersRead)).
+			Get("/{id}", handle(userCtrl.GetUser))
+		r.With(custommiddleware.RequirePermission(permissions, domain.PermissionUsersWrite)).
+			Post("/", handle(userCtrl.CreateUser))
+		r.With(custommiddleware.RequirePermission(permissions, domain.PermissionUsersWrite)).
+			Put("/{id}", handle(userCtrl.UpdateUser))
+		r.With(custommiddleware.RequirePermission(permissions, domain.PermissionUsersWrite)).
+			Patch("/{id}", handle(userCtrl.PatchUser))
+		r.With(custommiddleware.RequirePermission(permissions, domain.PermissionUsersDelete)).
+			Delete("/{id}", handle(userCtrl.DeleteUser))
+		r.With(custommiddleware.RequirePermission(permissions, domain.PermissionUsersAdmin)).
+			Post("/{id}:restore", handle(userCtrl.RestoreUser))
+	})
+
+	// API key routes. Keys are managed from a user session and scoped to the caller. They
+	// do not honor Idempotency-Key: a stored response would replay the key secret.
+	r.Route("/api-keys", func(r chi.Router) {
+		r.Use(authenticate...)
+		r.Post("/", handle(apiKeyCtrl.CreateAPIKey))
+		r.Get("/", handle(apiKeyCtrl.ListAPIKeys))
+		r.Delete("/{id}", handle(apiKeyCtrl.RevokeAPIKey))
+	})
+
+	// Audit routes. AuditService re-checks the permission.
+	r.Route("/audit", func(r chi.Router) {
+		r.Use(authenticate...)
+		r.With(custommiddleware.RequirePermission(permissions, domain.PermissionAuditRead)).
+			Get("/", handle(auditCtrl.ListEvents))
 	})
 
 	return r
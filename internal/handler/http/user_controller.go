@@ -7,8 +7,10 @@ import (
 	"github.com/go-chi/chi/v5"
 
 	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
 	"github.com/wonjinsin/go-boilerplate/internal/handler/http/dto"
 	"github.com/wonjinsin/go-boilerplate/internal/usecase"
+	pkgConstants "github.com/wonjinsin/go-boilerplate/pkg/constants"
 	"github.com/wonjinsin/go-boilerplate/pkg/errors"
 	"github.com/wonjinsin/go-boilerplate/pkg/logger"
 	"github.com/wonjinsin/go-boilerplate/pkg/utils"
@@ -16,131 +18,232 @@ import (
 
 // UserController handles user-related HTTP requests.
 type UserController struct {
-	svc usecase.UserService
+	svc          usecase.UserService
+	cursorSecret []byte
 }
 
 // NewUserController creates a new user controller.
-func NewUserController(svc usecase.UserService) *UserController {
-	return &UserController{svc: svc}
+// cursorSecret signs the opaque pagination cursors handed out to clients.
+func NewUserController(svc usecase.UserService, cursorSecret []byte) *UserController {
+	return &UserController{svc: svc, cursorSecret: cursorSecret}
 }
 
 // CreateUser handles user creation.
-func (c *UserController) CreateUser(w http.ResponseWriter, r *http.Request) {
+func (c *UserController) CreateUser(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 	logger.LogInfo(ctx, "CreateUser request received")
 
 	var req dto.CreateUserRequest
 	if err := utils.ParseJSONBody(r, &req); err != nil {
-		logger.LogWarn(ctx, "invalid json in request body")
-		utils.WriteStandardJSON(w, r, http.StatusBadRequest, dto.ErrorResult{
-			Msg: "invalid json",
-		}, string(constants.InvalidParameter))
-		return
-	}
-
-	u, err := c.svc.CreateUser(ctx, req.Name, req.Email)
-	if err != nil {
-		// Extract error code and determine HTTP status.
-		code := errors.GetCode(err)
-		var httpStatus int
-
-		switch code {
-		case constants.ConstraintError:
-			httpStatus = http.StatusConflict
-			logger.LogWarn(ctx, "duplicate email attempted")
-		case constants.InvalidParameter:
-			httpStatus = http.StatusBadRequest
-			logger.LogWarn(ctx, "invalid parameter in create user")
-		case "":
-			// Non-CustomError, default to 500.
-			code = constants.InternalError
-			httpStatus = http.StatusInternalServerError
-			logger.LogError(ctx, "unexpected error in create user", err)
-		default:
-			httpStatus = http.StatusInternalServerError
-			logger.LogError(ctx, "internal error in create user", err)
-		}
+		return err
+	}
 
-		utils.WriteStandardJSON(w, r, httpStatus, dto.ErrorResult{
-			Msg: err.Error(),
-		}, string(code))
-		return
+	u, err := c.svc.CreateUser(ctx, req.Name, req.Email, req.Password)
+	if err != nil {
+		return err
 	}
 
 	logger.LogInfo(ctx, "user created successfully")
+	w.Header().Set(pkgConstants.HeaderLocation, "/users/"+strconv.Itoa(u.ID))
+	setUserETag(w, u)
 	response := dto.ToUserResponse(u)
 	utils.WriteStandardJSON(w, r, http.StatusCreated, response)
+	return nil
 }
 
-// ListUsers handles user listing with pagination.
-func (c *UserController) ListUsers(w http.ResponseWriter, r *http.Request) {
+// ListUsers handles user listing with offset or cursor pagination.
+func (c *UserController) ListUsers(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 	logger.LogInfo(ctx, "ListUsers request received")
 
 	offset, limit := utils.ParsePagination(r)
-	list, err := c.svc.ListUsers(ctx, offset, limit)
+	includeDeleted, _ := strconv.ParseBool(r.URL.Query().Get("include_deleted"))
+	q := domain.UserListQuery{Offset: offset, Limit: limit, IncludeDeleted: includeDeleted}
+
+	filters, err := dto.ParseUserFilters(r.URL.Query()["filter"])
+	if err != nil {
+		return err
+	}
+	sorts, err := dto.ParseUserSort(r.URL.Query().Get("sort"))
 	if err != nil {
-		code := errors.GetCode(err)
-		if code == "" {
-			code = constants.InternalError
+		return err
+	}
+	q.Filters = filters
+	q.Sort = sorts
+
+	if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+		if err := dto.ApplyUserCursor(c.cursorSecret, cursor, &q); err != nil {
+			return err
 		}
-		logger.LogError(ctx, "failed to list users", err)
-		utils.WriteStandardJSON(w, r, http.StatusInternalServerError, dto.ErrorResult{
-			Msg: err.Error(),
-		}, string(code))
-		return
+		// Offsets are meaningless in cursor mode.
+		q.Offset = 0
+	}
+
+	page, err := c.svc.ListUsers(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	response, err := dto.ToUserListResponse(page, q.Offset, q.Limit, c.cursorSecret)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode pagination cursors")
 	}
 
 	logger.LogInfo(ctx, "users listed successfully")
-	response := dto.ToUserListResponse(list, len(list), offset, limit)
 	utils.WriteStandardJSON(w, r, http.StatusOK, response)
+	return nil
 }
 
 // GetUser handles retrieving a single user by ID.
-func (c *UserController) GetUser(w http.ResponseWriter, r *http.Request) {
+func (c *UserController) GetUser(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 	logger.LogInfo(ctx, "GetUser request received")
 
-	idStr := chi.URLParam(r, "id")
-	if idStr == "" {
-		logger.LogWarn(ctx, "user id is required but not provided")
-		utils.WriteStandardJSON(w, r, http.StatusBadRequest, dto.ErrorResult{
-			Msg: "user id is required",
-		}, string(constants.InvalidParameter))
-		return
-	}
-
-	id, err := strconv.Atoi(idStr)
+	id, err := parseUserID(r)
 	if err != nil {
-		logger.LogWarn(ctx, "invalid user id format")
-		utils.WriteStandardJSON(w, r, http.StatusBadRequest, dto.ErrorResult{
-			Msg: "invalid user id format",
-		}, string(constants.InvalidParameter))
-		return
+		return err
 	}
 
 	u, err := c.svc.GetUser(ctx, id)
 	if err != nil {
-		// Extract error code and determine HTTP status.
-		code := errors.GetCode(err)
-		var httpStatus int
-
-		switch code {
-		case constants.NotFound:
-			httpStatus = http.StatusNotFound
-			logger.LogWarn(ctx, "user not found")
-		default:
-			httpStatus = http.StatusInternalServerError
-			logger.LogError(ctx, "internal error in get user", err)
-		}
+		return err
+	}
 
-		utils.WriteStandardJSON(w, r, httpStatus, dto.ErrorResult{
-			Msg: err.Error(),
-		}, string(code))
-		return
+	setUserETag(w, u)
+	if notModified(w, r) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
 	}
 
 	logger.LogInfo(ctx, "user retrieved successfully")
 	response := dto.ToUserResponse(u)
 	utils.WriteStandardJSON(w, r, http.StatusOK, response)
+	return nil
+}
+
+// UpdateUser handles replacing a user's mutable fields.
+func (c *UserController) UpdateUser(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	logger.LogInfo(ctx, "UpdateUser request received")
+
+	id, err := parseUserID(r)
+	if err != nil {
+		return err
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateUserRequest
+	if err := utils.ParseJSONBody(r, &req); err != nil {
+		return err
+	}
+
+	u, err := c.svc.UpdateUser(ctx, id, version, req.Name, req.Email)
+	if err != nil {
+		return err
+	}
+
+	logger.LogInfo(ctx, "user updated successfully")
+	setUserETag(w, u)
+	response := dto.ToUserResponse(u)
+	utils.WriteStandardJSON(w, r, http.StatusOK, response)
+	return nil
+}
+
+// PatchUser handles partial user updates with JSON merge-patch semantics.
+func (c *UserController) PatchUser(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	logger.LogInfo(ctx, "PatchUser request received")
+
+	id, err := parseUserID(r)
+	if err != nil {
+		return err
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		return err
+	}
+
+	// Merge patches may be declared as such or as plain JSON.
+	var req dto.PatchUserRequest
+	err = utils.ParseJSONBody(r, &req, pkgConstants.ContentTypeJSON, pkgConstants.ContentTypeMergePatchJSON)
+	if err != nil {
+		return err
+	}
+
+	u, err := c.svc.PatchUser(ctx, id, version, req.Name, req.Email)
+	if err != nil {
+		return err
+	}
+
+	logger.LogInfo(ctx, "user patched successfully")
+	setUserETag(w, u)
+	response := dto.ToUserResponse(u)
+	utils.WriteStandardJSON(w, r, http.StatusOK, response)
+	return nil
+}
+
+// DeleteUser handles deleting a user by ID.
+func (c *UserController) DeleteUser(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	logger.LogInfo(ctx, "DeleteUser request received")
+
+	id, err := parseUserID(r)
+	if err != nil {
+		return err
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		return err
+	}
+
+	if err := c.svc.DeleteUser(ctx, id, version); err != nil {
+		return err
+	}
+
+	logger.LogInfo(ctx, "user deleted successfully")
+	utils.WriteStandardJSON(w, r, http.StatusOK, nil)
+	return nil
+}
+
+// RestoreUser handles restoring a soft-deleted user.
+func (c *UserController) RestoreUser(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	logger.LogInfo(ctx, "RestoreUser request received")
+
+	id, err := parseUserID(r)
+	if err != nil {
+		return err
+	}
+
+	u, err := c.svc.RestoreUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	logger.LogInfo(ctx, "user restored successfully")
+	setUserETag(w, u)
+	response := dto.ToUserResponse(u)
+	utils.WriteStandardJSON(w, r, http.StatusOK, response)
+	return nil
+}
+
+// parseUserID extracts the user ID path parameter.
+func parseUserID(r *http.Request) (int, error) {
+	idStr := chi.URLParam(r, "id")
+	if idStr == "" {
+		return 0, errors.New(constants.InvalidParameter, "user id is required", nil)
+	}
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return 0, errors.New(constants.InvalidParameter, "invalid user id format", err)
+	}
+
+	return id, nil
 }
@@ -0,0 +1,57 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/mock/gomock"
+
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/mock"
+	pkgConstants "github.com/wonjinsin/go-boilerplate/pkg/constants"
+)
+
+func TestPatchUserContentTypes(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		wantStatus  int
+	}{
+		{name: "json", contentType: "application/json", wantStatus: http.StatusOK},
+		{name: "json with charset", contentType: "application/json; charset=utf-8", wantStatus: http.StatusOK},
+		{name: "merge patch", contentType: "application/merge-patch+json", wantStatus: http.StatusOK},
+		{name: "json patch", contentType: "application/json-patch+json", wantStatus: http.StatusUnsupportedMediaType},
+		{name: "missing", wantStatus: http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			svc := mock.NewMockUserService(ctrl)
+			if tt.wantStatus == http.StatusOK {
+				name := "Jane Doe"
+				svc.EXPECT().
+					PatchUser(gomock.Any(), 1, 3, &name, nil).
+					Return(&domain.User{ID: 1, Name: name, Email: "jane@example.com", Version: 4}, nil)
+			}
+
+			router := chi.NewRouter()
+			router.Patch("/users/{id}", handle(NewUserController(svc, []byte("secret")).PatchUser))
+
+			r := httptest.NewRequest(http.MethodPatch, "/users/1", strings.NewReader(`{"name":"Jane Doe"}`))
+			if tt.contentType != "" {
+				r.Header.Set(pkgConstants.HeaderContentType, tt.contentType)
+			}
+			r.Header.Set(pkgConstants.HeaderIfMatch, `"3"`)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package postgres
+
+import (
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent"
+)
+
+// toDomainAPIKey converts ent.APIKey to domain.APIKey.
+func toDomainAPIKey(k *ent.APIKey) *domain.APIKey {
+	return &domain.APIKey{
+		ID:         k.ID,
+		OwnerID:    k.OwnerID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		KeyHash:    k.KeyHash,
+		Scopes:     domain.Permissions(k.Scopes),
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt,
+	}
+}
@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/internal/repository"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/apikey"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+)
+
+type apiKeyRepo struct {
+	client  *ent.Client
+	timeout time.Duration
+}
+
+// NewAPIKeyRepository creates a new PostgreSQL-based API key repository.
+func NewAPIKeyRepository(client *ent.Client, timeout time.Duration) repository.APIKeyRepository {
+	return &apiKeyRepo{client: client, timeout: timeout}
+}
+
+// Save creates a new API key.
+func (r *apiKeyRepo) Save(ctx context.Context, k *domain.APIKey) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	created, err := clientFrom(ctx, r.client).APIKey.
+		Create().
+		SetOwnerID(k.OwnerID).
+		SetName(k.Name).
+		SetPrefix(k.Prefix).
+		SetKeyHash(k.KeyHash).
+		SetScopes(k.Scopes).
+		SetNillableExpiresAt(k.ExpiresAt).
+		SetCreatedAt(k.CreatedAt).
+		Save(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return errors.New(constants.ConstraintError, "duplicate api key", err)
+		}
+		return wrapError(ctx, err, "failed to create api key")
+	}
+
+	k.ID = created.ID
+	return nil
+}
+
+// FindByHash retrieves an API key by the hash of its secret.
+func (r *apiKeyRepo) FindByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	k, err := clientFrom(ctx, r.client).APIKey.
+		Query().
+		Where(apikey.KeyHash(keyHash)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New(constants.NotFound, "api key not found", err)
+		}
+		return nil, wrapError(ctx, err, "failed to find api key")
+	}
+
+	return toDomainAPIKey(k), nil
+}
+
+// ListByOwner retrieves every key of an owner, newest first.
+func (r *apiKeyRepo) ListByOwner(ctx context.Context, ownerID int) (domain.APIKeys, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	keys, err := clientFrom(ctx, r.client).APIKey.
+		Query().
+		Where(apikey.OwnerID(ownerID)).
+		Order(ent.Desc(apikey.FieldCreatedAt), ent.Desc(apikey.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, wrapError(ctx, err, "failed to list api keys")
+	}
+
+	result := make(domain.APIKeys, len(keys))
+	for i, k := range keys {
+		result[i] = toDomainAPIKey(k)
+	}
+	return result, nil
+}
+
+// Revoke revokes an owner's active key.
+func (r *apiKeyRepo) Revoke(ctx context.Context, id, ownerID int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	n, err := clientFrom(ctx, r.client).APIKey.
+		Update().
+		Where(apikey.ID(id), apikey.OwnerID(ownerID), apikey.RevokedAtIsNil()).
+		SetRevokedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return wrapError(ctx, err, "failed to revoke api key")
+	}
+	if n == 0 {
+		return errors.New(constants.NotFound, "active api key not found", nil)
+	}
+
+	return nil
+}
+
+// TouchLastUsed records when a key was last used to authenticate.
+func (r *apiKeyRepo) TouchLastUsed(ctx context.Context, id int, at time.Time) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	err := clientFrom(ctx, r.client).APIKey.
+		UpdateOneID(id).
+		SetLastUsedAt(at).
+		Exec(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return errors.New(constants.NotFound, "api key not found", err)
+		}
+		return wrapError(ctx, err, "failed to update api key usage")
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package postgres
+
+import (
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent"
+)
+
+// toDomainAuditEvent converts ent.AuditEvent to domain.AuditEvent.
+func toDomainAuditEvent(e *ent.AuditEvent) *domain.AuditEvent {
+	return &domain.AuditEvent{
+		ID:        e.ID,
+		Entity:    e.Entity,
+		EntityID:  e.EntityID,
+		Operation: domain.AuditOperation(e.Operation),
+		Actor:     e.Actor,
+		TrID:      e.TrID,
+		Before:    e.Before,
+		After:     e.After,
+		CreatedAt: e.CreatedAt,
+	}
+}
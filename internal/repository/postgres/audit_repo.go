@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/internal/repository"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/auditevent"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/predicate"
+)
+
+type auditRepo struct {
+	client  *ent.Client
+	timeout time.Duration
+}
+
+// NewAuditRepository creates a new PostgreSQL-based audit event repository.
+func NewAuditRepository(client *ent.Client, timeout time.Duration) repository.AuditRepository {
+	return &auditRepo{client: client, timeout: timeout}
+}
+
+// List retrieves a page of events, newest first.
+func (r *auditRepo) List(ctx context.Context, q domain.AuditQuery) (domain.AuditEvents, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	events, err := clientFrom(ctx, r.client).AuditEvent.
+		Query().
+		Where(auditPredicates(q)...).
+		Order(ent.Desc(auditevent.FieldID)).
+		Offset(q.Offset).
+		Limit(q.Limit).
+		All(ctx)
+	if err != nil {
+		return nil, wrapError(ctx, err, "failed to list audit events")
+	}
+
+	result := make(domain.AuditEvents, len(events))
+	for i, e := range events {
+		result[i] = toDomainAuditEvent(e)
+	}
+	return result, nil
+}
+
+// Count returns the number of events matching the query, ignoring its page.
+func (r *auditRepo) Count(ctx context.Context, q domain.AuditQuery) (int, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	n, err := clientFrom(ctx, r.client).AuditEvent.
+		Query().
+		Where(auditPredicates(q)...).
+		Count(ctx)
+	if err != nil {
+		return 0, wrapError(ctx, err, "failed to count audit events")
+	}
+	return n, nil
+}
+
+// auditPredicates selects the entity type and, if set, the entity of q.
+func auditPredicates(q domain.AuditQuery) []predicate.AuditEvent {
+	preds := []predicate.AuditEvent{auditevent.Entity(q.Entity)}
+	if q.EntityID != 0 {
+		preds = append(preds, auditevent.EntityID(q.EntityID))
+	}
+	return preds
+}
@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+)
+
+// withTimeout bounds a single repository call by the configured query timeout.
+// A non-positive timeout leaves the caller's deadline, if any, as the only bound.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// wrapError wraps a query error. Queries aborted because the caller went away or a
+// deadline passed get their own codes so they are not reported as internal errors.
+func wrapError(ctx context.Context, err error, message string) error {
+	switch {
+	case stderrors.Is(err, context.DeadlineExceeded) || stderrors.Is(ctx.Err(), context.DeadlineExceeded):
+		return errors.New(constants.Timeout, message, err)
+	case stderrors.Is(err, context.Canceled) || stderrors.Is(ctx.Err(), context.Canceled):
+		return errors.New(constants.Canceled, message, err)
+	default:
+		return errors.Wrap(err, message)
+	}
+}
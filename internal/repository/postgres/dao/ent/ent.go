@@ -12,6 +12,13 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/apikey"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/auditevent"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/idempotencykey"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/permission"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/ratelimitcounter"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/refreshtoken"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/role"
 	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/user"
 )
 
@@ -73,7 +80,14 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			user.Table: user.ValidColumn,
+			apikey.Table:           apikey.ValidColumn,
+			auditevent.Table:       auditevent.ValidColumn,
+			idempotencykey.Table:   idempotencykey.ValidColumn,
+			permission.Table:       permission.ValidColumn,
+			ratelimitcounter.Table: ratelimitcounter.ValidColumn,
+			refreshtoken.Table:     refreshtoken.ValidColumn,
+			role.Table:             role.ValidColumn,
+			user.Table:             user.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
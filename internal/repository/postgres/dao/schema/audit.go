@@ -0,0 +1,191 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"entgo.io/ent"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	gen "github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/auditevent"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/hook"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/user"
+	pkgConstants "github.com/wonjinsin/go-boilerplate/pkg/constants"
+)
+
+// redacted replaces sensitive values in audit events.
+const redacted = "[REDACTED]"
+
+// auditUserHook records every create, update and delete of users as audit events. The
+// events are written with the client of the mutation, so they commit or roll back with
+// it; mutations outside a transaction are rejected, and the user repository refuses
+// them before they reach the hook. Soft deletes reach the hook as
+// updates setting deleted_at and are recorded as deletes.
+func auditUserHook() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return hook.UserFunc(func(ctx context.Context, m *gen.UserMutation) (ent.Value, error) {
+			if _, err := m.Tx(); err != nil {
+				return nil, fmt.Errorf("audited user mutation %s must run in a transaction: %w", m.Op(), err)
+			}
+			// Audited rows are read whatever their deletion state.
+			readCtx := SkipSoftDelete(ctx)
+
+			var ids []int
+			before := map[int]*gen.User{}
+			if !m.Op().Is(ent.OpCreate) {
+				var err error
+				if ids, err = m.IDs(readCtx); err != nil {
+					return nil, fmt.Errorf("failed to find audited users: %w", err)
+				}
+				if before, err = loadUsers(readCtx, m.Client(), ids); err != nil {
+					return nil, err
+				}
+			}
+
+			v, err := next.Mutate(ctx, m)
+			if err != nil {
+				return nil, err
+			}
+
+			after := map[int]*gen.User{}
+			switch {
+			case m.Op().Is(ent.OpCreate):
+				created, ok := v.(*gen.User)
+				if !ok {
+					return nil, fmt.Errorf("unexpected user create result %T", v)
+				}
+				ids, after[created.ID] = []int{created.ID}, created
+			case m.Op().Is(ent.OpUpdate | ent.OpUpdateOne):
+				if after, err = loadUsers(readCtx, m.Client(), ids); err != nil {
+					return nil, err
+				}
+			}
+
+			op := userAuditOperation(m)
+			builders := make([]*gen.AuditEventCreate, 0, len(ids))
+			for _, id := range ids {
+				old, updated := before[id], after[id]
+				if old == nil && updated == nil {
+					continue
+				}
+				oldValues, newValues := diffUsers(old, updated)
+				// Updates that change nothing are not recorded.
+				if len(oldValues) == 0 && len(newValues) == 0 {
+					continue
+				}
+				builders = append(builders, newAuditEvent(ctx, m.Client(), id, op, oldValues, newValues))
+			}
+			if len(builders) > 0 {
+				if err := m.Client().AuditEvent.CreateBulk(builders...).Exec(ctx); err != nil {
+					return nil, fmt.Errorf("failed to record audit events: %w", err)
+				}
+			}
+
+			return v, nil
+		})
+	}
+}
+
+// userAuditOperation classifies a user mutation.
+func userAuditOperation(m *gen.UserMutation) auditevent.Operation {
+	switch {
+	case m.Op().Is(ent.OpCreate):
+		return auditevent.OperationCreate
+	case m.Op().Is(ent.OpDelete | ent.OpDeleteOne):
+		return auditevent.OperationDelete
+	}
+	if _, ok := m.DeletedAt(); ok {
+		return auditevent.OperationDelete
+	}
+	if m.DeletedAtCleared() {
+		return auditevent.OperationRestore
+	}
+	return auditevent.OperationUpdate
+}
+
+// newAuditEvent builds an event attributed to the caller and transaction of ctx.
+func newAuditEvent(
+	ctx context.Context,
+	client *gen.Client,
+	id int,
+	op auditevent.Operation,
+	before, after map[string]any,
+) *gen.AuditEventCreate {
+	actor, _ := ctx.Value(constants.ContextKeyUserID).(string)
+	trID, _ := ctx.Value(pkgConstants.ContextKeyTrID).(string)
+
+	c := client.AuditEvent.
+		Create().
+		SetEntity(domain.AuditEntityUser).
+		SetEntityID(id).
+		SetOperation(op).
+		SetActor(actor).
+		SetTrID(trID).
+		SetCreatedAt(time.Now())
+	if before != nil {
+		c.SetBefore(before)
+	}
+	if after != nil {
+		c.SetAfter(after)
+	}
+	return c
+}
+
+// loadUsers reads users by ID.
+func loadUsers(ctx context.Context, client *gen.Client, ids []int) (map[int]*gen.User, error) {
+	users, err := client.User.Query().Where(user.IDIn(ids...)).All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load audited users: %w", err)
+	}
+	byID := make(map[int]*gen.User, len(users))
+	for _, u := range users {
+		byID[u.ID] = u
+	}
+	return byID, nil
+}
+
+// diffUsers returns the fields that differ between two states of a user, as they were
+// before and after. A nil state contributes every field to the other side.
+func diffUsers(before, after *gen.User) (map[string]any, map[string]any) {
+	oldValues, newValues := userSnapshot(before), userSnapshot(after)
+	for field, v := range oldValues {
+		if nv, ok := newValues[field]; ok && nv == v {
+			delete(oldValues, field)
+			delete(newValues, field)
+		}
+	}
+	for _, values := range []map[string]any{oldValues, newValues} {
+		if hash, ok := values[user.FieldPasswordHash]; ok && hash != "" {
+			values[user.FieldPasswordHash] = redacted
+		}
+	}
+	if before == nil {
+		oldValues = nil
+	}
+	if after == nil {
+		newValues = nil
+	}
+	return oldValues, newValues
+}
+
+// userSnapshot returns the audited fields of u with comparable values.
+func userSnapshot(u *gen.User) map[string]any {
+	if u == nil {
+		return map[string]any{}
+	}
+	var deletedAt any
+	if u.DeletedAt != nil {
+		deletedAt = u.DeletedAt.UTC().Format(time.RFC3339Nano)
+	}
+	return map[string]any{
+		user.FieldName:         u.Name,
+		user.FieldEmail:        u.Email,
+		user.FieldPasswordHash: u.PasswordHash,
+		user.FieldCreatedAt:    u.CreatedAt.UTC().Format(time.RFC3339Nano),
+		user.FieldDeletedAt:    deletedAt,
+		user.FieldVersion:      u.Version,
+	}
+}
@@ -0,0 +1,57 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// AuditEvent holds the schema definition for the AuditEvent entity.
+// A row records one change of an audited entity, written by the audit hook in the
+// transaction of the change.
+type AuditEvent struct {
+	ent.Schema
+}
+
+// Fields of the AuditEvent.
+func (AuditEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("id"),
+		field.String("entity").
+			NotEmpty().
+			Immutable(),
+		field.Int("entity_id").
+			Immutable(),
+		field.Enum("operation").
+			Values("create", "update", "delete", "restore").
+			Immutable(),
+		// Actor is the authenticated user that made the change, empty for system changes.
+		field.String("actor").
+			Optional().
+			Immutable(),
+		field.String("tr_id").
+			Optional().
+			Immutable(),
+		// Before and after hold the changed fields only. A create has no before and a
+		// permanent delete no after.
+		field.JSON("before", map[string]any{}).
+			Optional().
+			Immutable(),
+		field.JSON("after", map[string]any{}).
+			Optional().
+			Immutable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Indexes of the AuditEvent.
+func (AuditEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("entity", "entity_id"),
+		index.Fields("created_at"),
+	}
+}
@@ -0,0 +1,57 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// IdempotencyKey holds the schema definition for the IdempotencyKey entity.
+// A row locks its key while the first request runs and then stores the response
+// replayed to retries.
+type IdempotencyKey struct {
+	ent.Schema
+}
+
+// Fields of the IdempotencyKey.
+func (IdempotencyKey) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("id"),
+		// Scope is the caller that sent the key, so keys of different callers never collide.
+		field.String("scope").
+			Immutable(),
+		field.String("key").
+			NotEmpty().
+			Immutable(),
+		field.String("fingerprint").
+			NotEmpty().
+			Immutable(),
+		field.Enum("status").
+			Values("in_progress", "completed"),
+		field.Int("response_status").
+			Optional(),
+		// ResponseHeaders holds the replayed response headers, such as Content-Type and ETag.
+		field.JSON("response_headers", map[string]string{}).
+			Optional(),
+		field.Bytes("response_body").
+			Optional(),
+		field.Time("expires_at"),
+		// LockedUntil is the lease of the request running an in-progress key. The request
+		// renews it while it runs, so only a key whose request died can be taken over.
+		field.Time("locked_until"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Indexes of the IdempotencyKey.
+func (IdempotencyKey) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("scope", "key").
+			Unique(),
+		index.Fields("expires_at"),
+	}
+}
@@ -0,0 +1,38 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// RateLimitCounter holds the schema definition for the RateLimitCounter entity.
+// Each row counts the requests of one client key in one fixed window, so limits are
+// shared by every server instance.
+type RateLimitCounter struct {
+	ent.Schema
+}
+
+// Fields of the RateLimitCounter.
+func (RateLimitCounter) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("id"),
+		field.String("key").
+			NotEmpty().
+			Immutable(),
+		field.Time("window_start").
+			Immutable(),
+		field.Int("count"),
+		field.Time("expires_at").
+			Immutable(),
+	}
+}
+
+// Indexes of the RateLimitCounter.
+func (RateLimitCounter) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("key", "window_start").
+			Unique(),
+		index.Fields("expires_at"),
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/index"
 )
@@ -13,6 +15,13 @@ type User struct {
 	ent.Schema
 }
 
+// Mixin of the User.
+func (User) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		SoftDeleteMixin{},
+	}
+}
+
 // Fields of the User.
 func (User) Fields() []ent.Field {
 	return []ent.Field{
@@ -20,11 +29,33 @@ func (User) Fields() []ent.Field {
 		field.String("name").
 			NotEmpty(),
 		field.String("email").
-			Unique().
 			NotEmpty(),
+		field.String("password_hash").
+			Optional().
+			Sensitive(),
 		field.Time("created_at").
 			Default(time.Now).
 			Immutable(),
+		// Version is incremented by every update, for optimistic concurrency control.
+		field.Int("version").
+			Default(1).
+			Positive(),
+	}
+}
+
+// Edges of the User.
+func (User) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("refresh_tokens", RefreshToken.Type),
+		edge.To("roles", Role.Type),
+		edge.To("api_keys", APIKey.Type),
+	}
+}
+
+// Hooks of the User.
+func (User) Hooks() []ent.Hook {
+	return []ent.Hook{
+		auditUserHook(),
 	}
 }
 
@@ -32,5 +63,9 @@ func (User) Fields() []ent.Field {
 func (User) Indexes() []ent.Index {
 	return []ent.Index{
 		index.Fields("created_at"),
+		// Email is unique among live users only, so a deleted user's email can be reused.
+		index.Fields("email").
+			Unique().
+			Annotations(entsql.IndexWhere("deleted_at IS NULL")),
 	}
 }
@@ -0,0 +1,23 @@
+package postgres
+
+import (
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent"
+)
+
+// toDomainIdempotencyRecord converts ent.IdempotencyKey to domain.IdempotencyRecord.
+func toDomainIdempotencyRecord(k *ent.IdempotencyKey) *domain.IdempotencyRecord {
+	return &domain.IdempotencyRecord{
+		ID:              k.ID,
+		Scope:           k.Scope,
+		Key:             k.Key,
+		Fingerprint:     k.Fingerprint,
+		Status:          domain.IdempotencyStatus(k.Status),
+		ResponseStatus:  k.ResponseStatus,
+		ResponseHeaders: k.ResponseHeaders,
+		ResponseBody:    k.ResponseBody,
+		ExpiresAt:       k.ExpiresAt,
+		LockedUntil:     k.LockedUntil,
+		CreatedAt:       k.CreatedAt,
+	}
+}
@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/internal/repository"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/idempotencykey"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+)
+
+type idempotencyRepo struct {
+	client  *ent.Client
+	timeout time.Duration
+}
+
+// NewIdempotencyRepository creates a new PostgreSQL-based idempotency key repository.
+func NewIdempotencyRepository(client *ent.Client, timeout time.Duration) repository.IdempotencyRepository {
+	return &idempotencyRepo{client: client, timeout: timeout}
+}
+
+// Create inserts an in-progress record. The unique (scope, key) index makes the insert
+// a lock: of concurrent requests with the same key, only one succeeds.
+func (r *idempotencyRepo) Create(ctx context.Context, rec *domain.IdempotencyRecord) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	created, err := clientFrom(ctx, r.client).IdempotencyKey.
+		Create().
+		SetScope(rec.Scope).
+		SetKey(rec.Key).
+		SetFingerprint(rec.Fingerprint).
+		SetStatus(idempotencykey.Status(rec.Status)).
+		SetExpiresAt(rec.ExpiresAt).
+		SetLockedUntil(rec.LockedUntil).
+		SetCreatedAt(rec.CreatedAt).
+		Save(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return errors.New(constants.ConstraintError, "duplicate idempotency key", err)
+		}
+		return wrapError(ctx, err, "failed to create idempotency key")
+	}
+
+	rec.ID = created.ID
+	return nil
+}
+
+// Find retrieves the record of a key sent by scope.
+func (r *idempotencyRepo) Find(ctx context.Context, scope, key string) (*domain.IdempotencyRecord, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	k, err := clientFrom(ctx, r.client).IdempotencyKey.
+		Query().
+		Where(idempotencykey.Scope(scope), idempotencykey.Key(key)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New(constants.NotFound, "idempotency key not found", err)
+		}
+		return nil, wrapError(ctx, err, "failed to find idempotency key")
+	}
+
+	return toDomainIdempotencyRecord(k), nil
+}
+
+// Complete stores the response of an in-progress record.
+func (r *idempotencyRepo) Complete(ctx context.Context, rec *domain.IdempotencyRecord) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	n, err := clientFrom(ctx, r.client).IdempotencyKey.
+		Update().
+		Where(
+			idempotencykey.ID(rec.ID),
+			idempotencykey.StatusEQ(idempotencykey.StatusInProgress),
+		).
+		SetStatus(idempotencykey.Status(rec.Status)).
+		SetResponseStatus(rec.ResponseStatus).
+		SetResponseHeaders(rec.ResponseHeaders).
+		SetResponseBody(rec.ResponseBody).
+		Save(ctx)
+	if err != nil {
+		return wrapError(ctx, err, "failed to complete idempotency key")
+	}
+	if n == 0 {
+		return errors.New(constants.NotFound, "in-progress idempotency key not found", nil)
+	}
+
+	return nil
+}
+
+// RenewLease stores the lease of an in-progress record.
+func (r *idempotencyRepo) RenewLease(ctx context.Context, rec *domain.IdempotencyRecord) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	n, err := clientFrom(ctx, r.client).IdempotencyKey.
+		Update().
+		Where(
+			idempotencykey.ID(rec.ID),
+			idempotencykey.StatusEQ(idempotencykey.StatusInProgress),
+		).
+		SetLockedUntil(rec.LockedUntil).
+		Save(ctx)
+	if err != nil {
+		return wrapError(ctx, err, "failed to renew idempotency key")
+	}
+	if n == 0 {
+		return errors.New(constants.NotFound, "in-progress idempotency key not found", nil)
+	}
+
+	return nil
+}
+
+// Delete removes a record, releasing its key.
+func (r *idempotencyRepo) Delete(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if err := clientFrom(ctx, r.client).IdempotencyKey.DeleteOneID(id).Exec(ctx); err != nil {
+		if ent.IsNotFound(err) {
+			return errors.New(constants.NotFound, "idempotency key not found", err)
+		}
+		return wrapError(ctx, err, "failed to delete idempotency key")
+	}
+
+	return nil
+}
+
+// DeleteExpired removes every record that expired before now.
+func (r *idempotencyRepo) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	n, err := clientFrom(ctx, r.client).IdempotencyKey.
+		Delete().
+		Where(idempotencykey.ExpiresAtLTE(now)).
+		Exec(ctx)
+	if err != nil {
+		return 0, wrapError(ctx, err, "failed to delete expired idempotency keys")
+	}
+
+	return n, nil
+}
@@ -0,0 +1,19 @@
+package postgres
+
+import (
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent"
+)
+
+// toDomainRefreshToken converts ent.RefreshToken to domain.RefreshToken.
+func toDomainRefreshToken(t *ent.RefreshToken) *domain.RefreshToken {
+	return &domain.RefreshToken{
+		ID:        t.ID,
+		UserID:    t.UserID,
+		FamilyID:  t.FamilyID,
+		TokenHash: t.TokenHash,
+		ExpiresAt: t.ExpiresAt,
+		RevokedAt: t.RevokedAt,
+		CreatedAt: t.CreatedAt,
+	}
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/internal/repository"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/refreshtoken"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+)
+
+type refreshTokenRepo struct {
+	client  *ent.Client
+	timeout time.Duration
+}
+
+// NewRefreshTokenRepository creates a new PostgreSQL-based refresh token repository.
+func NewRefreshTokenRepository(client *ent.Client, timeout time.Duration) repository.RefreshTokenRepository {
+	return &refreshTokenRepo{client: client, timeout: timeout}
+}
+
+// Save creates a new refresh token.
+func (r *refreshTokenRepo) Save(ctx context.Context, t *domain.RefreshToken) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	created, err := clientFrom(ctx, r.client).RefreshToken.
+		Create().
+		SetUserID(t.UserID).
+		SetFamilyID(t.FamilyID).
+		SetTokenHash(t.TokenHash).
+		SetExpiresAt(t.ExpiresAt).
+		SetCreatedAt(t.CreatedAt).
+		Save(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return errors.New(constants.ConstraintError, "duplicate refresh token", err)
+		}
+		return wrapError(ctx, err, "failed to create refresh token")
+	}
+
+	t.ID = created.ID
+	return nil
+}
+
+// FindByHash retrieves a refresh token by the hash of its secret.
+func (r *refreshTokenRepo) FindByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	t, err := clientFrom(ctx, r.client).RefreshToken.
+		Query().
+		Where(refreshtoken.TokenHash(tokenHash)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New(constants.NotFound, "refresh token not found", err)
+		}
+		return nil, wrapError(ctx, err, "failed to find refresh token")
+	}
+
+	return toDomainRefreshToken(t), nil
+}
+
+// Revoke marks an active refresh token as revoked.
+// The conditional update makes concurrent rotations of the same token race-free:
+// only one caller sees the token as active.
+func (r *refreshTokenRepo) Revoke(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	n, err := clientFrom(ctx, r.client).RefreshToken.
+		Update().
+		Where(refreshtoken.ID(id), refreshtoken.RevokedAtIsNil()).
+		SetRevokedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return wrapError(ctx, err, "failed to revoke refresh token")
+	}
+	if n == 0 {
+		return errors.New(constants.NotFound, "active refresh token not found", nil)
+	}
+
+	return nil
+}
+
+// RevokeFamily revokes every active refresh token in a family.
+func (r *refreshTokenRepo) RevokeFamily(ctx context.Context, familyID string) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := clientFrom(ctx, r.client).RefreshToken.
+		Update().
+		Where(refreshtoken.FamilyID(familyID), refreshtoken.RevokedAtIsNil()).
+		SetRevokedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return wrapError(ctx, err, "failed to revoke refresh token family")
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/internal/repository"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/permission"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/role"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/user"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+)
+
+type roleRepo struct {
+	client  *ent.Client
+	timeout time.Duration
+}
+
+// NewRoleRepository creates a new PostgreSQL-based role repository.
+func NewRoleRepository(client *ent.Client, timeout time.Duration) repository.RoleRepository {
+	return &roleRepo{client: client, timeout: timeout}
+}
+
+// PermissionsForUser returns the distinct permissions granted to a user through its roles.
+// A soft-deleted user has none, so tokens issued before the deletion stop authorizing.
+func (r *roleRepo) PermissionsForUser(ctx context.Context, userID int) (domain.Permissions, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	names, err := clientFrom(ctx, r.client).Permission.
+		Query().
+		Where(permission.HasRolesWith(role.HasUsersWith(user.ID(userID), user.DeletedAtIsNil()))).
+		Unique(true).
+		Select(permission.FieldName).
+		Strings(ctx)
+	if err != nil {
+		return nil, wrapError(ctx, err, "failed to load user permissions")
+	}
+
+	return domain.Permissions(names), nil
+}
+
+// AssignRole grants the named role to a user.
+func (r *roleRepo) AssignRole(ctx context.Context, userID int, name string) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	client := clientFrom(ctx, r.client)
+	found, err := client.Role.Query().Where(role.Name(name)).Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return errors.New(constants.NotFound, "role not found", err)
+		}
+		return wrapError(ctx, err, "failed to find role")
+	}
+
+	held, err := found.QueryUsers().Where(user.ID(userID)).Exist(ctx)
+	if err != nil {
+		return wrapError(ctx, err, "failed to check role assignment")
+	}
+	if held {
+		return nil
+	}
+
+	if err := client.Role.UpdateOne(found).AddUserIDs(userID).Exec(ctx); err != nil {
+		return wrapError(ctx, err, "failed to assign role")
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/repository"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+)
+
+// PostgreSQL error codes that mean the transaction lost a conflict and can be retried as a whole.
+const (
+	sqlStateSerializationFailure = "40001"
+	sqlStateDeadlockDetected     = "40P01"
+)
+
+// txRetryBackoff is the base delay between transaction attempts; it grows linearly.
+const txRetryBackoff = 10 * time.Millisecond
+
+// TxOptions configures transactions started by the transaction manager.
+type TxOptions struct {
+	Isolation sql.IsolationLevel
+	// MaxRetries is how many times a transaction is re-run after a serialization failure.
+	MaxRetries int
+}
+
+type txManager struct {
+	client *ent.Client
+	opts   TxOptions
+}
+
+// NewTxManager creates a transaction manager over the ent client.
+func NewTxManager(client *ent.Client, opts TxOptions) repository.TxManager {
+	return &txManager{client: client, opts: opts}
+}
+
+// WithinTx runs fn in a transaction stored in the context passed to fn, so every repository
+// called with that context takes part in it. The transaction commits if fn returns nil and
+// rolls back if fn returns an error or panics. Serialization failures and deadlocks re-run fn
+// from the start, up to MaxRetries times. Calls nested inside a transaction join it.
+func (m *txManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ent.TxFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := m.runTx(ctx, fn)
+		if err == nil || attempt >= m.opts.MaxRetries || !isRetryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return wrapError(ctx, ctx.Err(), "transaction retry aborted")
+		case <-time.After(time.Duration(attempt+1) * txRetryBackoff):
+		}
+	}
+}
+
+// runTx runs a single transaction attempt.
+func (m *txManager) runTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	tx, err := m.client.BeginTx(ctx, &sql.TxOptions{Isolation: m.opts.Isolation})
+	if err != nil {
+		return wrapError(ctx, err, "failed to begin transaction")
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(ent.NewTxContext(ctx, tx)); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			return errors.Wrap(err, "failed to roll back transaction: "+rerr.Error())
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return wrapError(ctx, err, "failed to commit transaction")
+	}
+	return nil
+}
+
+// isRetryable reports whether err is a serialization failure or deadlock.
+func isRetryable(err error) bool {
+	var pgErr interface{ SQLState() string }
+	if !stderrors.As(err, &pgErr) {
+		return false
+	}
+	switch pgErr.SQLState() {
+	case sqlStateSerializationFailure, sqlStateDeadlockDetected:
+		return true
+	default:
+		return false
+	}
+}
+
+// clientFrom returns the client bound to the context's transaction, or the root client.
+func clientFrom(ctx context.Context, root *ent.Client) *ent.Client {
+	if tx := ent.TxFromContext(ctx); tx != nil {
+		return tx.Client()
+	}
+	return root
+}
+
+// txClientFrom returns the client bound to the context's transaction. Mutations whose
+// hooks write in the same transaction, such as audited ones, must not run without it.
+func txClientFrom(ctx context.Context, operation string) (*ent.Client, error) {
+	tx := ent.TxFromContext(ctx)
+	if tx == nil {
+		return nil, errors.New(constants.InternalError,
+			operation+" must run inside TxManager.WithinTx", nil)
+	}
+	return tx.Client(), nil
+}
+
+// ParseIsolationLevel parses an isolation level name such as "read_committed",
+// "repeatable_read" or "serializable". An empty name selects the database default.
+func ParseIsolationLevel(name string) (sql.IsolationLevel, error) {
+	switch strings.ToLower(strings.NewReplacer("-", "_", " ", "_").Replace(name)) {
+	case "", "default":
+		return sql.LevelDefault, nil
+	case "read_committed":
+		return sql.LevelReadCommitted, nil
+	case "repeatable_read":
+		return sql.LevelRepeatableRead, nil
+	case "serializable":
+		return sql.LevelSerializable, nil
+	default:
+		return sql.LevelDefault, fmt.Errorf("unsupported isolation level %q", name)
+	}
+}
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/predicate"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/user"
+)
+
+// userColumns maps domain field names to user table columns.
+var userColumns = map[string]string{
+	domain.UserFieldID:        user.FieldID,
+	domain.UserFieldName:      user.FieldName,
+	domain.UserFieldEmail:     user.FieldEmail,
+	domain.UserFieldCreatedAt: user.FieldCreatedAt,
+}
+
+// toUserPredicates translates validated domain filters into ent predicates.
+func toUserPredicates(filters []domain.UserFilter) ([]predicate.User, error) {
+	preds := make([]predicate.User, 0, len(filters))
+	for _, f := range filters {
+		p, err := toUserPredicate(f)
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, p)
+	}
+	return preds, nil
+}
+
+// toUserPredicate translates a single domain filter into an ent predicate.
+func toUserPredicate(f domain.UserFilter) (predicate.User, error) {
+	column, ok := userColumns[f.Field]
+	if !ok {
+		return nil, fmt.Errorf("unknown user filter field %q", f.Field)
+	}
+
+	if f.Field == domain.UserFieldCreatedAt {
+		switch f.Op {
+		case domain.FilterOpEq:
+			return user.CreatedAtEQ(f.Time), nil
+		case domain.FilterOpGreater:
+			return user.CreatedAtGT(f.Time), nil
+		case domain.FilterOpGreaterEq:
+			return user.CreatedAtGTE(f.Time), nil
+		case domain.FilterOpLess:
+			return user.CreatedAtLT(f.Time), nil
+		case domain.FilterOpLessEq:
+			return user.CreatedAtLTE(f.Time), nil
+		}
+		return nil, fmt.Errorf("unknown user filter operator %q", f.Op)
+	}
+
+	switch f.Op {
+	case domain.FilterOpEq:
+		return predicate.User(sql.FieldEQ(column, f.Value)), nil
+	case domain.FilterOpPrefix:
+		return predicate.User(sql.FieldHasPrefix(column, f.Value)), nil
+	case domain.FilterOpContains:
+		return predicate.User(sql.FieldContains(column, f.Value)), nil
+	case domain.FilterOpIEq:
+		return predicate.User(sql.FieldEqualFold(column, f.Value)), nil
+	case domain.FilterOpIPrefix:
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(sql.HasPrefixFold(s.C(column), f.Value))
+		}), nil
+	case domain.FilterOpIContains:
+		return predicate.User(sql.FieldContainsFold(column, f.Value)), nil
+	}
+	return nil, fmt.Errorf("unknown user filter operator %q", f.Op)
+}
+
+// toUserOrder translates domain sort keys into ent order options.
+// The primary key is always appended as a tie-breaker so pages are deterministic.
+func toUserOrder(sorts []domain.UserSort) ([]user.OrderOption, error) {
+	if len(sorts) == 0 {
+		return []user.OrderOption{ent.Asc(user.FieldCreatedAt), ent.Asc(user.FieldID)}, nil
+	}
+
+	orders := make([]user.OrderOption, 0, len(sorts)+1)
+	hasID := false
+	for _, s := range sorts {
+		hasID = hasID || s.Field == domain.UserFieldID
+		column, ok := userColumns[s.Field]
+		if !ok {
+			return nil, fmt.Errorf("unknown user sort field %q", s.Field)
+		}
+		if s.Desc {
+			orders = append(orders, ent.Desc(column))
+		} else {
+			orders = append(orders, ent.Asc(column))
+		}
+	}
+	if !hasID {
+		orders = append(orders, ent.Asc(user.FieldID))
+	}
+	return orders, nil
+}
@@ -8,15 +8,18 @@ import (
 // toDomainUser converts ent.User to domain.User.
 func toDomainUser(u *ent.User) *domain.User {
 	return &domain.User{
-		ID:        u.ID,
-		Name:      u.Name,
-		Email:     u.Email,
-		CreatedAt: u.CreatedAt,
+		ID:           u.ID,
+		Name:         u.Name,
+		Email:        u.Email,
+		PasswordHash: u.PasswordHash,
+		CreatedAt:    u.CreatedAt,
+		DeletedAt:    u.DeletedAt,
+		Version:      u.Version,
 	}
 }
 
 // toEntUserData converts domain.User to ent values for creation/update.
 // This is where you can add transformation logic if needed.
-func toEntUserData(u *domain.User) (name string, email string) {
-	return u.Name, u.Email
+func toEntUserData(u *domain.User) (name, email, passwordHash string) {
+	return u.Name, u.Email, u.PasswordHash
 }
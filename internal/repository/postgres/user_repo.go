@@ -2,51 +2,72 @@ package postgres
 
 import (
 	"context"
-	"fmt"
+	"slices"
+	"time"
 
 	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/database"
 	"github.com/wonjinsin/go-boilerplate/internal/domain"
 	"github.com/wonjinsin/go-boilerplate/internal/repository"
 	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent"
 	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/ent/user"
+	"github.com/wonjinsin/go-boilerplate/internal/repository/postgres/dao/schema"
 	"github.com/wonjinsin/go-boilerplate/pkg/errors"
 )
 
 type userRepo struct {
-	client *ent.Client
+	client  *ent.Client
+	timeout time.Duration
 }
 
 // NewUserRepository creates a new PostgreSQL-based user repository.
-func NewUserRepository(client *ent.Client) repository.UserRepository {
-	return &userRepo{client: client}
+func NewUserRepository(client *ent.Client, timeout time.Duration) repository.UserRepository {
+	return &userRepo{client: client, timeout: timeout}
 }
 
-// Save creates or updates a user.
-func (r *userRepo) Save(u *domain.User) error {
-	ctx := context.Background()
+// Save creates or updates a user. It must run in a transaction, see UserRepository.
+func (r *userRepo) Save(ctx context.Context, u *domain.User) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	client, err := txClientFrom(ctx, "saving a user")
+	if err != nil {
+		return err
+	}
 
 	// Apply transformations using mapper.
-	name, email := toEntUserData(u)
+	name, email, passwordHash := toEntUserData(u)
 
 	// Check if user already exists.
 	if u.ID != 0 {
-		// Update existing user.
-		_, err := r.client.User.
-			UpdateOneID(u.ID).
+		// Update existing user, only if nobody saved it since it was loaded.
+		n, err := client.User.
+			Update().
+			Where(user.ID(u.ID), user.Version(u.Version), user.DeletedAtIsNil()).
 			SetName(name).
 			SetEmail(email).
+			SetPasswordHash(passwordHash).
+			AddVersion(1).
 			Save(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to update user: %w", err)
+			if ent.IsConstraintError(err) {
+				return errors.New(constants.ConstraintError, "duplicate email", err)
+			}
+			return wrapError(ctx, err, "failed to update user")
+		}
+		if n == 0 {
+			return r.updateMissError(ctx, u.ID)
 		}
+		u.Version++
 		return nil
 	}
 
 	// Create new user.
-	created, err := r.client.User.
+	created, err := client.User.
 		Create().
 		SetName(name).
 		SetEmail(email).
+		SetPasswordHash(passwordHash).
 		SetCreatedAt(u.CreatedAt).
 		Save(ctx)
 	if err != nil {
@@ -54,19 +75,33 @@ func (r *userRepo) Save(u *domain.User) error {
 		if ent.IsConstraintError(err) {
 			return errors.New(constants.ConstraintError, "duplicate email", err)
 		}
-		return errors.Wrap(err, "failed to create user")
+		return wrapError(ctx, err, "failed to create user")
 	}
 
 	// Update domain object with generated ID.
 	u.ID = created.ID
+	u.Version = created.Version
 	return nil
 }
 
-// FindByID retrieves a user by ID.
-func (r *userRepo) FindByID(id int) (*domain.User, error) {
-	ctx := context.Background()
+// updateMissError explains why a versioned update of user id matched no row.
+func (r *userRepo) updateMissError(ctx context.Context, id int) error {
+	exists, err := clientFrom(ctx, r.client).User.Query().Where(user.ID(id)).Exist(ctx)
+	if err != nil {
+		return wrapError(ctx, err, "failed to check user")
+	}
+	if !exists {
+		return errors.New(constants.NotFound, "user not found", nil)
+	}
+	return errors.New(constants.PreconditionFail, "user has been modified", nil)
+}
 
-	u, err := r.client.User.
+// FindByID retrieves a user by ID. Outside a transaction it may read from the replica.
+func (r *userRepo) FindByID(ctx context.Context, id int) (*domain.User, error) {
+	ctx, cancel := withTimeout(database.ReadFromReplica(ctx), r.timeout)
+	defer cancel()
+
+	u, err := clientFrom(ctx, r.client).User.
 		Query().
 		Where(user.ID(id)).
 		Only(ctx)
@@ -74,17 +109,18 @@ func (r *userRepo) FindByID(id int) (*domain.User, error) {
 		if ent.IsNotFound(err) {
 			return nil, errors.New(constants.NotFound, "user not found", err)
 		}
-		return nil, errors.Wrap(err, "failed to find user")
+		return nil, wrapError(ctx, err, "failed to find user")
 	}
 
 	return toDomainUser(u), nil
 }
 
 // FindByEmail retrieves a user by email.
-func (r *userRepo) FindByEmail(email string) (*domain.User, error) {
-	ctx := context.Background()
+func (r *userRepo) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
 
-	u, err := r.client.User.
+	u, err := clientFrom(ctx, r.client).User.
 		Query().
 		Where(user.EmailEQ(email)).
 		Only(ctx)
@@ -92,30 +128,144 @@ func (r *userRepo) FindByEmail(email string) (*domain.User, error) {
 		if ent.IsNotFound(err) {
 			return nil, errors.New(constants.NotFound, "user not found", err)
 		}
-		return nil, errors.Wrap(err, "failed to find user by email")
+		return nil, wrapError(ctx, err, "failed to find user by email")
 	}
 
 	return toDomainUser(u), nil
 }
 
-// List retrieves a list of users with pagination.
-func (r *userRepo) List(offset, limit int) (domain.Users, error) {
-	ctx := context.Background()
+// List retrieves a filtered, sorted page of users.
+// Keyset pagination over (created_at, id) is used when the query carries a cursor,
+// offset pagination otherwise. Backward pages are returned in ascending order as well.
+// Outside a transaction it may read from the replica.
+func (r *userRepo) List(ctx context.Context, q domain.UserListQuery) (domain.Users, error) {
+	ctx, cancel := withTimeout(database.ReadFromReplica(listContext(ctx, q)), r.timeout)
+	defer cancel()
 
-	users, err := r.client.User.
-		Query().
-		Order(ent.Asc(user.FieldCreatedAt)).
-		Offset(offset).
-		Limit(limit).
-		All(ctx)
+	preds, err := toUserPredicates(q.Filters)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to list users")
+		return nil, errors.New(constants.InvalidParameter, "invalid user filter", err)
+	}
+	query := clientFrom(ctx, r.client).User.Query().Where(preds...)
+
+	switch {
+	case q.After != nil:
+		query = query.
+			Where(user.Or(
+				user.CreatedAtGT(q.After.CreatedAt),
+				user.And(user.CreatedAtEQ(q.After.CreatedAt), user.IDGT(q.After.ID)),
+			)).
+			Order(ent.Asc(user.FieldCreatedAt), ent.Asc(user.FieldID))
+	case q.Before != nil:
+		query = query.
+			Where(user.Or(
+				user.CreatedAtLT(q.Before.CreatedAt),
+				user.And(user.CreatedAtEQ(q.Before.CreatedAt), user.IDLT(q.Before.ID)),
+			)).
+			Order(ent.Desc(user.FieldCreatedAt), ent.Desc(user.FieldID))
+	default:
+		orders, err := toUserOrder(q.Sort)
+		if err != nil {
+			return nil, errors.New(constants.InvalidParameter, "invalid user sort", err)
+		}
+		query = query.Order(orders...).Offset(q.Offset)
+	}
+
+	users, err := query.Limit(q.Limit).All(ctx)
+	if err != nil {
+		return nil, wrapError(ctx, err, "failed to list users")
 	}
 
 	result := make(domain.Users, len(users))
 	for i, u := range users {
 		result[i] = toDomainUser(u)
 	}
+	if q.IsBackward() {
+		slices.Reverse(result)
+	}
 
 	return result, nil
 }
+
+// Count returns the total number of users matching the query filters, ignoring pagination.
+// Outside a transaction it may read from the replica.
+func (r *userRepo) Count(ctx context.Context, q domain.UserListQuery) (int, error) {
+	ctx, cancel := withTimeout(database.ReadFromReplica(listContext(ctx, q)), r.timeout)
+	defer cancel()
+
+	preds, err := toUserPredicates(q.Filters)
+	if err != nil {
+		return 0, errors.New(constants.InvalidParameter, "invalid user filter", err)
+	}
+
+	total, err := clientFrom(ctx, r.client).User.Query().Where(preds...).Count(ctx)
+	if err != nil {
+		return 0, wrapError(ctx, err, "failed to count users")
+	}
+
+	return total, nil
+}
+
+// Delete soft-deletes a user by ID, only if it is still at version when that is set.
+func (r *userRepo) Delete(ctx context.Context, id, version int) error {
+	ctx, cancel := withTimeout(ctx, r.timeout)
+	defer cancel()
+
+	client, err := txClientFrom(ctx, "deleting a user")
+	if err != nil {
+		return err
+	}
+
+	del := client.User.DeleteOneID(id)
+	if version != 0 {
+		del.Where(user.Version(version))
+	}
+	if err := del.Exec(ctx); err != nil {
+		if ent.IsNotFound(err) {
+			if version != 0 {
+				return r.updateMissError(ctx, id)
+			}
+			return errors.New(constants.NotFound, "user not found", err)
+		}
+		return wrapError(ctx, err, "failed to delete user")
+	}
+
+	return nil
+}
+
+// Restore clears the deletion mark of a soft-deleted user.
+func (r *userRepo) Restore(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(schema.SkipSoftDelete(ctx), r.timeout)
+	defer cancel()
+
+	client, err := txClientFrom(ctx, "restoring a user")
+	if err != nil {
+		return err
+	}
+
+	_, err = client.User.
+		UpdateOneID(id).
+		Where(user.DeletedAtNotNil()).
+		ClearDeletedAt().
+		AddVersion(1).
+		Save(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return errors.New(constants.NotFound, "deleted user not found", err)
+		}
+		if ent.IsConstraintError(err) {
+			return errors.New(constants.ConstraintError, "duplicate email", err)
+		}
+		return wrapError(ctx, err, "failed to restore user")
+	}
+
+	return nil
+}
+
+// listContext returns the query context for listing, including soft-deleted users if requested.
+func listContext(ctx context.Context, q domain.UserListQuery) context.Context {
+	if q.IncludeDeleted {
+		ctx = schema.SkipSoftDelete(ctx)
+	}
+	return ctx
+}
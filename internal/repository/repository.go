@@ -1,11 +1,92 @@
 package repository
 
-import "github.com/wonjinsin/go-boilerplate/internal/domain"
+import (
+	"context"
+	"time"
+
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+)
+
+// TxManager runs units of work in a database transaction.
+type TxManager interface {
+	// WithinTx runs fn in a transaction carried by the context passed to fn. Repositories
+	// called with that context take part in the transaction.
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
 
 // UserRepository defines the interface for user data access.
+// Save, Delete and Restore are audited in the same transaction, so they must run inside
+// TxManager.WithinTx; outside a transaction they fail with InternalError.
 type UserRepository interface {
-	Save(*domain.User) error
-	FindByID(id int) (*domain.User, error)
-	FindByEmail(email string) (*domain.User, error)
-	List(offset, limit int) (domain.Users, error)
+	Save(ctx context.Context, u *domain.User) error
+	FindByID(ctx context.Context, id int) (*domain.User, error)
+	FindByEmail(ctx context.Context, email string) (*domain.User, error)
+	List(ctx context.Context, q domain.UserListQuery) (domain.Users, error)
+	Count(ctx context.Context, q domain.UserListQuery) (int, error)
+	// Delete fails with PreconditionFail unless version is the current version of the
+	// user; zero skips the check.
+	Delete(ctx context.Context, id, version int) error
+	Restore(ctx context.Context, id int) error
+}
+
+// RefreshTokenRepository defines the interface for refresh token data access.
+type RefreshTokenRepository interface {
+	Save(ctx context.Context, t *domain.RefreshToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error)
+	// Revoke marks an active token as revoked, returning NotFound if it was already revoked.
+	Revoke(ctx context.Context, id int) error
+	RevokeFamily(ctx context.Context, familyID string) error
+}
+
+// APIKeyRepository defines the interface for API key data access.
+type APIKeyRepository interface {
+	Save(ctx context.Context, k *domain.APIKey) error
+	FindByHash(ctx context.Context, keyHash string) (*domain.APIKey, error)
+	ListByOwner(ctx context.Context, ownerID int) (domain.APIKeys, error)
+	// Revoke revokes an owner's active key. It returns NotFound if no such active key exists.
+	Revoke(ctx context.Context, id, ownerID int) error
+	TouchLastUsed(ctx context.Context, id int, at time.Time) error
+}
+
+// RoleRepository defines the interface for role and permission data access.
+type RoleRepository interface {
+	// PermissionsForUser returns the distinct permissions granted to a user through its roles.
+	PermissionsForUser(ctx context.Context, userID int) (domain.Permissions, error)
+	// AssignRole grants the named role to a user, returning NotFound if no such role
+	// exists. Assigning a role the user already holds does nothing.
+	AssignRole(ctx context.Context, userID int, role string) error
+}
+
+// IdempotencyRepository defines the interface for idempotency key data access.
+type IdempotencyRepository interface {
+	// Create inserts an in-progress record, returning ConstraintError if the scope
+	// already holds the key.
+	Create(ctx context.Context, rec *domain.IdempotencyRecord) error
+	Find(ctx context.Context, scope, key string) (*domain.IdempotencyRecord, error)
+	// Complete stores the response of an in-progress record.
+	Complete(ctx context.Context, rec *domain.IdempotencyRecord) error
+	// RenewLease stores the lease of an in-progress record, returning NotFound if the
+	// record was completed, released or taken over.
+	RenewLease(ctx context.Context, rec *domain.IdempotencyRecord) error
+	Delete(ctx context.Context, id int) error
+	// DeleteExpired removes every record that expired before now and returns how many.
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
+
+// RateLimitRepository defines the interface for rate limit counter data access.
+type RateLimitRepository interface {
+	// Increment adds one to the count of key in the window starting at start and
+	// returns the new count. Concurrent increments of the same window are not lost.
+	Increment(ctx context.Context, key string, start, expiresAt time.Time) (int, error)
+	// Count returns the count of key in the window starting at start, zero when absent.
+	Count(ctx context.Context, key string, start time.Time) (int, error)
+	// DeleteExpired removes every counter that expired before now and returns how many.
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
+
+// AuditRepository defines the interface for audit event data access. Events are written
+// by the audit hook of each audited entity, so only reads are exposed.
+type AuditRepository interface {
+	List(ctx context.Context, q domain.AuditQuery) (domain.AuditEvents, error)
+	Count(ctx context.Context, q domain.AuditQuery) (int, error)
 }
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/internal/repository"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+	"github.com/wonjinsin/go-boilerplate/pkg/utils"
+)
+
+type adminBootstrapper struct {
+	tx     repository.TxManager
+	users  repository.UserRepository
+	roles  repository.RoleRepository
+	hasher domain.PasswordHasher
+}
+
+// NewAdminBootstrapper creates an AdminBootstrapper.
+func NewAdminBootstrapper(
+	tx repository.TxManager,
+	users repository.UserRepository,
+	roles repository.RoleRepository,
+	hasher domain.PasswordHasher,
+) AdminBootstrapper {
+	return &adminBootstrapper{tx: tx, users: users, roles: roles, hasher: hasher}
+}
+
+// BootstrapAdmin finds or creates the user and grants it the admin role in one
+// transaction, so the creation is audited and nothing is kept if the grant fails.
+func (b *adminBootstrapper) BootstrapAdmin(
+	ctx context.Context,
+	email, name, password string,
+) (*domain.User, bool, error) {
+	var (
+		admin   *domain.User
+		created bool
+	)
+	err := b.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Each attempt starts over, since a retried attempt must not see rolled back state.
+		admin, created = nil, false
+
+		existing, err := b.users.FindByEmail(ctx, utils.NormalizeEmail(email))
+		switch {
+		case err == nil:
+			admin = existing
+		case !errors.HasCode(err, constants.NotFound):
+			return errors.Wrap(err, "failed to find user")
+		case password == "":
+			return errors.New(constants.InvalidParameter, "a password is required to create the admin user", nil)
+		default:
+			u, err := domain.NewUser(0, name, email, time.Now())
+			if err != nil {
+				return errors.Wrap(err, "failed to create user")
+			}
+			if err := u.SetPassword(password, b.hasher); err != nil {
+				return errors.Wrap(err, "failed to create user")
+			}
+			if err := b.users.Save(ctx, u); err != nil {
+				return errors.Wrap(err, "failed to save user")
+			}
+			admin, created = u, true
+		}
+
+		if err := b.roles.AssignRole(ctx, admin.ID, domain.RoleAdmin); err != nil {
+			return errors.Wrap(err, "failed to grant admin role")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return admin, created, nil
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/internal/repository"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+	"github.com/wonjinsin/go-boilerplate/pkg/utils"
+)
+
+const (
+	apiKeyMarker       = "gbk_"
+	apiKeyPrefixLength = 8
+	apiKeySecretLength = 43 // ~256 bits of entropy.
+	// apiKeyTouchInterval limits last_used_at writes to one per key per interval.
+	apiKeyTouchInterval = time.Minute
+)
+
+type apiKeyService struct {
+	keys  repository.APIKeyRepository
+	users repository.UserRepository
+	authz Authorizer
+}
+
+// NewAPIKeyService creates an API key service.
+func NewAPIKeyService(
+	keys repository.APIKeyRepository,
+	users repository.UserRepository,
+	authz Authorizer,
+) APIKeyService {
+	return &apiKeyService{keys: keys, users: users, authz: authz}
+}
+
+// IssueAPIKey creates a key owned by the caller. Scopes must be permissions the caller holds,
+// and keys can only be issued from a user session, not by another API key.
+func (s *apiKeyService) IssueAPIKey(
+	ctx context.Context,
+	name string,
+	scopes []string,
+	expiresAt *time.Time,
+) (*domain.APIKey, string, error) {
+	ownerID, err := s.requireUserSession(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	perms, err := s.authz.Permissions(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, scope := range scopes {
+		if !perms.Has(scope) {
+			return nil, "", errors.New(constants.Forbidden, "cannot grant scope "+scope, nil)
+		}
+	}
+
+	prefix, err := utils.GenerateRandomID(apiKeyPrefixLength)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to generate api key")
+	}
+	secret, err := utils.GenerateRandomID(apiKeySecretLength)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to generate api key")
+	}
+	prefix = apiKeyMarker + prefix
+	key := prefix + "." + secret
+
+	k, err := domain.NewAPIKey(ownerID, name, prefix, utils.HashSHA256(key),
+		domain.Permissions(scopes), expiresAt, time.Now())
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to create api key")
+	}
+	if err := s.keys.Save(ctx, k); err != nil {
+		return nil, "", errors.Wrap(err, "failed to save api key")
+	}
+	return k, key, nil
+}
+
+// ListAPIKeys returns the caller's keys, including revoked and expired ones.
+func (s *apiKeyService) ListAPIKeys(ctx context.Context) (domain.APIKeys, error) {
+	ownerID, err := s.requireUserSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := s.keys.ListByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list api keys")
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey revokes one of the caller's keys.
+func (s *apiKeyService) RevokeAPIKey(ctx context.Context, id int) error {
+	ownerID, err := s.requireUserSession(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.keys.Revoke(ctx, id, ownerID); err != nil {
+		return errors.Wrap(err, "failed to revoke api key")
+	}
+	return nil
+}
+
+// AuthenticateAPIKey resolves a presented key. Unknown, revoked and expired keys, and keys
+// of deleted users, are all reported as Unauthorized.
+func (s *apiKeyService) AuthenticateAPIKey(ctx context.Context, key string) (*domain.APIKey, error) {
+	k, err := s.keys.FindByHash(ctx, utils.HashSHA256(key))
+	if err != nil {
+		if errors.HasCode(err, constants.NotFound) {
+			return nil, errors.New(constants.Unauthorized, "invalid api key", nil)
+		}
+		return nil, errors.Wrap(err, "failed to find api key")
+	}
+
+	now := time.Now()
+	if k.IsRevoked() || k.IsExpired(now) {
+		return nil, errors.New(constants.Unauthorized, "invalid api key", nil)
+	}
+	if _, err := s.users.FindByID(ctx, k.OwnerID); err != nil {
+		if errors.HasCode(err, constants.NotFound) {
+			return nil, errors.New(constants.Unauthorized, "invalid api key", nil)
+		}
+		return nil, errors.Wrap(err, "failed to find api key owner")
+	}
+
+	if k.LastUsedAt == nil || now.Sub(*k.LastUsedAt) >= apiKeyTouchInterval {
+		// Usage tracking is best effort and must not fail authentication.
+		if err := s.keys.TouchLastUsed(ctx, k.ID, now); err == nil {
+			k.LastUsedAt = &now
+		}
+	}
+	return k, nil
+}
+
+// requireUserSession returns the caller's user ID, rejecting callers authenticated by API key.
+func (s *apiKeyService) requireUserSession(ctx context.Context) (int, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if isAPIKeyCaller(ctx) {
+		return 0, errors.New(constants.Forbidden, "api keys cannot manage api keys", nil)
+	}
+	return userID, nil
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/internal/repository"
+	pkgConstants "github.com/wonjinsin/go-boilerplate/pkg/constants"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+)
+
+// auditedEntities are the entity types whose changes are recorded.
+var auditedEntities = []string{domain.AuditEntityUser}
+
+type auditService struct {
+	repo  repository.AuditRepository
+	authz Authorizer
+}
+
+// NewAuditService creates an audit log service.
+func NewAuditService(repo repository.AuditRepository, authz Authorizer) AuditService {
+	return &auditService{repo: repo, authz: authz}
+}
+
+// ListEvents returns a page of the events of q.Entity, newest first.
+func (s *auditService) ListEvents(ctx context.Context, q domain.AuditQuery) (*domain.AuditPage, error) {
+	if err := s.authz.Require(ctx, domain.PermissionAuditRead); err != nil {
+		return nil, err
+	}
+	if !slices.Contains(auditedEntities, q.Entity) {
+		return nil, errors.New(constants.InvalidParameter, "unknown audit entity", nil)
+	}
+	if q.Limit <= 0 {
+		q.Limit = pkgConstants.DefaultLimit
+	}
+
+	events, err := s.repo.List(ctx, q)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list audit events")
+	}
+	total, err := s.repo.Count(ctx, q)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to count audit events")
+	}
+
+	return &domain.AuditPage{Events: events, Total: total}, nil
+}
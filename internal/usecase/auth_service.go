@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/internal/repository"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+	"github.com/wonjinsin/go-boilerplate/pkg/utils"
+)
+
+const (
+	refreshTokenLength = 43 // ~256 bits of entropy.
+	familyIDLength     = 22
+)
+
+type authService struct {
+	tx         repository.TxManager
+	users      repository.UserRepository
+	tokens     repository.RefreshTokenRepository
+	issuer     AccessTokenIssuer
+	hasher     domain.PasswordHasher
+	refreshTTL time.Duration
+	// dummyHash is compared against when the email is unknown or has no password,
+	// so response timing does not reveal which emails are registered.
+	dummyHash string
+}
+
+func NewAuthService(
+	tx repository.TxManager,
+	users repository.UserRepository,
+	tokens repository.RefreshTokenRepository,
+	issuer AccessTokenIssuer,
+	hasher domain.PasswordHasher,
+	refreshTTL time.Duration,
+) AuthService {
+	dummyHash, _ := hasher.Hash("dummy-password-for-timing")
+	return &authService{
+		tx:         tx,
+		users:      users,
+		tokens:     tokens,
+		issuer:     issuer,
+		hasher:     hasher,
+		refreshTTL: refreshTTL,
+		dummyHash:  dummyHash,
+	}
+}
+
+// Login verifies email and password credentials and starts a new refresh token family.
+func (s *authService) Login(ctx context.Context, email, password string) (*domain.AuthTokens, error) {
+	u, err := s.users.FindByEmail(ctx, utils.NormalizeEmail(email))
+	if err != nil && !errors.HasCode(err, constants.NotFound) {
+		return nil, errors.Wrap(err, "failed to find user")
+	}
+	if !s.checkPassword(u, password) {
+		return nil, errors.New(constants.Unauthorized, "invalid email or password", nil)
+	}
+
+	familyID, err := utils.GenerateRandomID(familyIDLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate token family")
+	}
+	return s.issueTokens(ctx, u.ID, familyID)
+}
+
+// checkPassword reports whether password is the password of u. Unknown users (nil) and
+// users without a password cost the same hash comparison as everyone else.
+func (s *authService) checkPassword(u *domain.User, password string) bool {
+	if u == nil || u.PasswordHash == "" {
+		_ = s.hasher.Compare(s.dummyHash, password)
+		return false
+	}
+	return u.CheckPassword(password, s.hasher)
+}
+
+// Refresh rotates a refresh token: the presented token is revoked and a new pair is
+// issued in the same family. Presenting a token that was already rotated indicates it
+// was stolen, so the whole family is revoked.
+func (s *authService) Refresh(ctx context.Context, refreshToken string) (*domain.AuthTokens, error) {
+	t, err := s.tokens.FindByHash(ctx, utils.HashSHA256(refreshToken))
+	if err != nil {
+		if errors.HasCode(err, constants.NotFound) {
+			return nil, errors.New(constants.Unauthorized, "invalid refresh token", nil)
+		}
+		return nil, errors.Wrap(err, "failed to find refresh token")
+	}
+
+	if t.IsRevoked() {
+		return nil, s.revokeReusedFamily(ctx, t.FamilyID)
+	}
+	if t.IsExpired(time.Now()) {
+		return nil, errors.New(constants.Unauthorized, "refresh token expired", nil)
+	}
+
+	// Rotation is atomic; the family revocation after a lost race must commit on its own,
+	// so it runs outside the transaction.
+	var tokens *domain.AuthTokens
+	var reused bool
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		reused = false
+		if err := s.tokens.Revoke(ctx, t.ID); err != nil {
+			// Lost a race with a concurrent refresh of the same token.
+			if errors.HasCode(err, constants.NotFound) {
+				reused = true
+				return nil
+			}
+			return errors.Wrap(err, "failed to rotate refresh token")
+		}
+
+		if _, err := s.users.FindByID(ctx, t.UserID); err != nil {
+			if errors.HasCode(err, constants.NotFound) {
+				return errors.New(constants.Unauthorized, "user no longer exists", nil)
+			}
+			return errors.Wrap(err, "failed to get user")
+		}
+
+		issued, err := s.issueTokens(ctx, t.UserID, t.FamilyID)
+		if err != nil {
+			return err
+		}
+		tokens = issued
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		return nil, s.revokeReusedFamily(ctx, t.FamilyID)
+	}
+	return tokens, nil
+}
+
+// Logout revokes the refresh token family the presented token belongs to.
+func (s *authService) Logout(ctx context.Context, refreshToken string) error {
+	t, err := s.tokens.FindByHash(ctx, utils.HashSHA256(refreshToken))
+	if err != nil {
+		if errors.HasCode(err, constants.NotFound) {
+			return errors.New(constants.Unauthorized, "invalid refresh token", nil)
+		}
+		return errors.Wrap(err, "failed to find refresh token")
+	}
+
+	if err := s.tokens.RevokeFamily(ctx, t.FamilyID); err != nil {
+		return errors.Wrap(err, "failed to revoke refresh tokens")
+	}
+	return nil
+}
+
+// issueTokens issues an access token and a new refresh token in the given family.
+func (s *authService) issueTokens(
+	ctx context.Context,
+	userID int,
+	familyID string,
+) (*domain.AuthTokens, error) {
+	accessToken, accessExpiresAt, err := s.issuer.Issue(strconv.Itoa(userID))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to issue access token")
+	}
+
+	secret, err := utils.GenerateRandomID(refreshTokenLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate refresh token")
+	}
+	t := domain.NewRefreshToken(userID, familyID, utils.HashSHA256(secret), time.Now(), s.refreshTTL)
+	if err := s.tokens.Save(ctx, t); err != nil {
+		return nil, errors.Wrap(err, "failed to save refresh token")
+	}
+
+	return &domain.AuthTokens{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessExpiresAt,
+		RefreshToken:          secret,
+		RefreshTokenExpiresAt: t.ExpiresAt,
+	}, nil
+}
+
+// revokeReusedFamily revokes a family after a rotated token was presented again.
+func (s *authService) revokeReusedFamily(ctx context.Context, familyID string) error {
+	if err := s.tokens.RevokeFamily(ctx, familyID); err != nil {
+		return errors.Wrap(err, "failed to revoke refresh tokens")
+	}
+	return errors.New(constants.Unauthorized, "refresh token reuse detected", nil)
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/internal/repository"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+)
+
+type authorizer struct {
+	roles repository.RoleRepository
+}
+
+// NewAuthorizer creates an Authorizer that resolves permissions through the user's roles.
+func NewAuthorizer(roles repository.RoleRepository) Authorizer {
+	return &authorizer{roles: roles}
+}
+
+// WithPermissions returns a context carrying an already resolved permission set.
+// Non-HTTP entry points (jobs, consumers) use it to act with an explicit set of permissions.
+func WithPermissions(ctx context.Context, perms domain.Permissions) context.Context {
+	return context.WithValue(ctx, constants.ContextKeyPermissions, perms)
+}
+
+// Permissions returns the caller's permissions, preferring a set cached in the context
+// over loading them for the authenticated user ID. Callers authenticated with an API key
+// only get the key's scopes that the owner still holds.
+func (a *authorizer) Permissions(ctx context.Context) (domain.Permissions, error) {
+	if perms, ok := ctx.Value(constants.ContextKeyPermissions).(domain.Permissions); ok {
+		return perms, nil
+	}
+
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	perms, err := a.roles.PermissionsForUser(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to resolve permissions")
+	}
+
+	if scopes, ok := ctx.Value(constants.ContextKeyAPIKeyScopes).(domain.Permissions); ok {
+		granted := make(domain.Permissions, 0, len(scopes))
+		for _, scope := range scopes {
+			if perms.Has(scope) {
+				granted = append(granted, scope)
+			}
+		}
+		perms = granted
+	}
+	return perms, nil
+}
+
+// Require returns a Forbidden error unless the caller holds the permission.
+func (a *authorizer) Require(ctx context.Context, permission string) error {
+	perms, err := a.Permissions(ctx)
+	if err != nil {
+		return err
+	}
+	if !perms.Has(permission) {
+		return errors.New(constants.Forbidden, "missing permission "+permission, nil)
+	}
+	return nil
+}
+
+// callerID returns the authenticated user ID carried in the context.
+func callerID(ctx context.Context) (int, error) {
+	subject, _ := ctx.Value(constants.ContextKeyUserID).(string)
+	if subject == "" {
+		return 0, errors.New(constants.Unauthorized, "unauthenticated caller", nil)
+	}
+	userID, err := strconv.Atoi(subject)
+	if err != nil {
+		return 0, errors.New(constants.Unauthorized, "invalid subject", err)
+	}
+	return userID, nil
+}
+
+// isAPIKeyCaller reports whether the caller authenticated with an API key.
+func isAPIKeyCaller(ctx context.Context) bool {
+	_, ok := ctx.Value(constants.ContextKeyAPIKeyScopes).(domain.Permissions)
+	return ok
+}
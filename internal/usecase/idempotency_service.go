@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/internal/repository"
+	"github.com/wonjinsin/go-boilerplate/pkg/errors"
+)
+
+// idempotencyClaimAttempts bounds how often Begin retries after replacing a stale record.
+const idempotencyClaimAttempts = 3
+
+type idempotencyService struct {
+	repo  repository.IdempotencyRepository
+	ttl   time.Duration
+	lease time.Duration
+}
+
+// NewIdempotencyService creates an idempotency service. Responses are replayed for ttl.
+// A running request holds its key for lease at a time and renews it; a key whose lease
+// ended without renewal belongs to a request that died and is taken over by a retry.
+func NewIdempotencyService(
+	repo repository.IdempotencyRepository,
+	ttl time.Duration,
+	lease time.Duration,
+) IdempotencyService {
+	return &idempotencyService{repo: repo, ttl: ttl, lease: lease}
+}
+
+// Begin claims key by inserting an in-progress record. If the key is taken, the existing
+// record decides: a completed one with the same fingerprint is replayed, an in-progress
+// one is a concurrent duplicate, and a different fingerprint is a reused key. Expired and
+// abandoned records are replaced.
+func (s *idempotencyService) Begin(
+	ctx context.Context,
+	scope, key, fingerprint string,
+) (*domain.IdempotencyRecord, error) {
+	now := time.Now()
+	rec := domain.NewIdempotencyRecord(scope, key, fingerprint, s.ttl, s.lease, now)
+
+	for range idempotencyClaimAttempts {
+		err := s.repo.Create(ctx, rec)
+		if err == nil {
+			return rec, nil
+		}
+		if !errors.HasCode(err, constants.ConstraintError) {
+			return nil, errors.Wrap(err, "failed to claim idempotency key")
+		}
+
+		existing, err := s.repo.Find(ctx, scope, key)
+		if errors.HasCode(err, constants.NotFound) {
+			// Released or purged since the insert failed; claim again.
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find idempotency key")
+		}
+
+		if existing.IsExpired(now) || existing.IsAbandoned(now) {
+			if err := s.repo.Delete(ctx, existing.ID); err != nil && !errors.HasCode(err, constants.NotFound) {
+				return nil, errors.Wrap(err, "failed to replace idempotency key")
+			}
+			continue
+		}
+		if existing.Fingerprint != fingerprint {
+			return nil, errors.New(constants.Unprocessable,
+				"idempotency key was already used for a different request", nil)
+		}
+		if existing.Status == domain.IdempotencyInProgress {
+			return nil, errors.New(constants.ConstraintError,
+				"a request with this idempotency key is in progress", nil)
+		}
+		return existing, nil
+	}
+
+	return nil, errors.New(constants.ConstraintError, "a request with this idempotency key is in progress", nil)
+}
+
+// Complete stores the response of a claimed record.
+func (s *idempotencyService) Complete(ctx context.Context, rec *domain.IdempotencyRecord) error {
+	if err := s.repo.Complete(ctx, rec); err != nil {
+		return errors.Wrap(err, "failed to store idempotent response")
+	}
+	return nil
+}
+
+// RenewLease extends the lease of a claimed record by the configured lease.
+func (s *idempotencyService) RenewLease(ctx context.Context, rec *domain.IdempotencyRecord) error {
+	rec.RenewLease(s.lease, time.Now())
+	if err := s.repo.RenewLease(ctx, rec); err != nil {
+		return errors.Wrap(err, "failed to renew idempotency key")
+	}
+	return nil
+}
+
+// Release deletes a claimed record.
+func (s *idempotencyService) Release(ctx context.Context, rec *domain.IdempotencyRecord) error {
+	if err := s.repo.Delete(ctx, rec.ID); err != nil && !errors.HasCode(err, constants.NotFound) {
+		return errors.Wrap(err, "failed to release idempotency key")
+	}
+	return nil
+}
+
+// PurgeExpired removes expired records.
+func (s *idempotencyService) PurgeExpired(ctx context.Context) (int, error) {
+	n, err := s.repo.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to purge idempotency keys")
+	}
+	return n, nil
+}
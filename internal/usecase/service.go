@@ -2,13 +2,82 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/wonjinsin/go-boilerplate/internal/domain"
 )
 
 // UserService defines the interface for user business logic.
 type UserService interface {
-	CreateUser(ctx context.Context, name, email string) (*domain.User, error)
+	CreateUser(ctx context.Context, name, email, password string) (*domain.User, error)
 	GetUser(ctx context.Context, id int) (*domain.User, error)
-	ListUsers(ctx context.Context, offset, limit int) (domain.Users, error)
+	ListUsers(ctx context.Context, q domain.UserListQuery) (*domain.UserPage, error)
+	// UpdateUser, PatchUser and DeleteUser fail with PreconditionFail unless version is
+	// the current version of the user; zero skips the check.
+	UpdateUser(ctx context.Context, id, version int, name, email string) (*domain.User, error)
+	PatchUser(ctx context.Context, id, version int, name, email *string) (*domain.User, error)
+	DeleteUser(ctx context.Context, id, version int) error
+	RestoreUser(ctx context.Context, id int) (*domain.User, error)
+}
+
+// AdminBootstrapper creates the first administrator of a deployment. It is meant for
+// operator tools and performs no permission checks.
+type AdminBootstrapper interface {
+	// BootstrapAdmin grants the admin role to the user with email, first creating it with
+	// name and password if it does not exist, and reports whether it was created.
+	BootstrapAdmin(ctx context.Context, email, name, password string) (*domain.User, bool, error)
+}
+
+// AuthService defines the interface for first-party authentication.
+type AuthService interface {
+	Login(ctx context.Context, email, password string) (*domain.AuthTokens, error)
+	Refresh(ctx context.Context, refreshToken string) (*domain.AuthTokens, error)
+	Logout(ctx context.Context, refreshToken string) error
+}
+
+// APIKeyService defines the interface for issuing and authenticating API keys.
+type APIKeyService interface {
+	// IssueAPIKey creates a key for the caller and returns it with its secret,
+	// which is not retrievable afterwards.
+	IssueAPIKey(
+		ctx context.Context,
+		name string,
+		scopes []string,
+		expiresAt *time.Time,
+	) (*domain.APIKey, string, error)
+	ListAPIKeys(ctx context.Context) (domain.APIKeys, error)
+	RevokeAPIKey(ctx context.Context, id int) error
+	AuthenticateAPIKey(ctx context.Context, key string) (*domain.APIKey, error)
+}
+
+// AuditService defines the interface for reading the audit log.
+type AuditService interface {
+	ListEvents(ctx context.Context, q domain.AuditQuery) (*domain.AuditPage, error)
+}
+
+// Authorizer checks the permissions of the caller carried in the context.
+type Authorizer interface {
+	Permissions(ctx context.Context) (domain.Permissions, error)
+	Require(ctx context.Context, permission string) error
+}
+
+// AccessTokenIssuer issues signed access tokens for a subject.
+type AccessTokenIssuer interface {
+	Issue(subject string) (token string, expiresAt time.Time, err error)
+}
+
+// IdempotencyService makes retried requests replay the outcome of the first one.
+type IdempotencyService interface {
+	// Begin claims key for the request identified by fingerprint. It returns an
+	// in-progress record when the caller should run the request and then Complete or
+	// Release it, or a completed record whose response should be replayed.
+	Begin(ctx context.Context, scope, key, fingerprint string) (*domain.IdempotencyRecord, error)
+	Complete(ctx context.Context, rec *domain.IdempotencyRecord) error
+	// RenewLease extends the lease of an in-progress record. The caller renews it while
+	// the request runs; a record whose lease ends is taken over by the next retry.
+	RenewLease(ctx context.Context, rec *domain.IdempotencyRecord) error
+	// Release forgets an in-progress record, so a retry runs the request again.
+	Release(ctx context.Context, rec *domain.IdempotencyRecord) error
+	// PurgeExpired removes expired records and returns how many.
+	PurgeExpired(ctx context.Context) (int, error)
 }
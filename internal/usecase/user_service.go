@@ -7,28 +7,35 @@ import (
 	"github.com/wonjinsin/go-boilerplate/internal/constants"
 	"github.com/wonjinsin/go-boilerplate/internal/domain"
 	"github.com/wonjinsin/go-boilerplate/internal/repository"
+	shared "github.com/wonjinsin/go-boilerplate/internal/shared/utils"
 	"github.com/wonjinsin/go-boilerplate/pkg/errors"
 )
 
 type userService struct {
-	repo repository.UserRepository
+	tx     repository.TxManager
+	repo   repository.UserRepository
+	hasher domain.PasswordHasher
+	authz  Authorizer
 }
 
-func NewUserService(r repository.UserRepository) UserService {
-	return &userService{repo: r}
+// NewUserService creates a user service. Every method checks the caller's permissions
+// through authz, so the checks hold for non-HTTP entry points as well.
+func NewUserService(
+	tx repository.TxManager,
+	r repository.UserRepository,
+	hasher domain.PasswordHasher,
+	authz Authorizer,
+) UserService {
+	return &userService{tx: tx, repo: r, hasher: hasher, authz: authz}
 }
 
-func (s *userService) CreateUser(_ context.Context, name, email string) (*domain.User, error) {
-	// Check if user with email already exists.
-	existing, err := s.repo.FindByEmail(email)
-	if err != nil {
-		// If error is NotFound, it's okay - user doesn't exist yet.
-		if !errors.HasCode(err, constants.NotFound) {
-			return nil, errors.Wrap(err, "failed to check existing email")
-		}
-	} else if existing != nil {
-		// User exists - duplicate email.
-		return nil, errors.New(constants.ConstraintError, "duplicate email", nil)
+// CreateUser registers a new user. An empty password creates a user without login credentials.
+func (s *userService) CreateUser(
+	ctx context.Context,
+	name, email, password string,
+) (*domain.User, error) {
+	if err := s.authz.Require(ctx, domain.PermissionUsersWrite); err != nil {
+		return nil, err
 	}
 
 	// ID is 0 - database will auto-generate.
@@ -36,27 +43,220 @@ func (s *userService) CreateUser(_ context.Context, name, email string) (*domain
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create user")
 	}
-	if err := s.repo.Save(u); err != nil {
-		return nil, errors.Wrap(err, "failed to save user")
+	if password != "" {
+		if err := u.SetPassword(password, s.hasher); err != nil {
+			return nil, errors.Wrap(err, "failed to create user")
+		}
 	}
-	return u, nil
+
+	// The email check and the insert run in one transaction so concurrent sign-ups
+	// with the same email cannot both pass the check. Each attempt saves a fresh copy,
+	// since a retried attempt must not see the ID assigned by a rolled back insert.
+	var created *domain.User
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := s.repo.FindByEmail(ctx, u.Email)
+		if err != nil {
+			// If error is NotFound, it's okay - user doesn't exist yet.
+			if !errors.HasCode(err, constants.NotFound) {
+				return errors.Wrap(err, "failed to check existing email")
+			}
+		} else if existing != nil {
+			// User exists - duplicate email.
+			return errors.New(constants.ConstraintError, "duplicate email", nil)
+		}
+
+		attempt := *u
+		if err := s.repo.Save(ctx, &attempt); err != nil {
+			return errors.Wrap(err, "failed to save user")
+		}
+		created = &attempt
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
 }
 
-func (s *userService) GetUser(_ context.Context, id int) (*domain.User, error) {
-	u, err := s.repo.FindByID(id)
+func (s *userService) GetUser(ctx context.Context, id int) (*domain.User, error) {
+	if err := s.authz.Require(ctx, domain.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+	u, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get user")
 	}
 	return u, nil
 }
 
-func (s *userService) ListUsers(_ context.Context, offset, limit int) (domain.Users, error) {
-	if limit <= 0 {
-		limit = 50
+func (s *userService) ListUsers(ctx context.Context, q domain.UserListQuery) (*domain.UserPage, error) {
+	if err := s.authz.Require(ctx, domain.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+	if q.IncludeDeleted {
+		if err := s.authz.Require(ctx, domain.PermissionUsersAdmin); err != nil {
+			return nil, err
+		}
+	}
+	if q.Limit <= 0 {
+		q.Limit = 50
 	}
-	users, err := s.repo.List(offset, limit)
+	if q.IsKeyset() && q.HasCustomSort() {
+		return nil, errors.New(constants.InvalidParameter,
+			"cursor pagination only supports the default sort order", nil)
+	}
+
+	// Fetch one extra row to find out whether another page exists in the paging direction.
+	limit := q.Limit
+	q.Limit++
+	users, err := s.repo.List(ctx, q)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list users")
 	}
-	return users, nil
+	q.Limit = limit
+
+	hasMore := len(users) > limit
+	if hasMore {
+		if q.IsBackward() {
+			users = users[1:]
+		} else {
+			users = users[:limit]
+		}
+	}
+
+	total, err := s.repo.Count(ctx, q)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to count users")
+	}
+
+	// Cursors encode (created_at, id) positions, so they are only handed out for that ordering.
+	page := &domain.UserPage{Users: users, Total: total}
+	if len(users) == 0 || q.HasCustomSort() {
+		return page, nil
+	}
+	first, last := users[0], users[len(users)-1]
+	if q.IsBackward() {
+		page.NextCursor = last.CursorOf()
+		if hasMore {
+			page.PrevCursor = first.CursorOf()
+		}
+	} else {
+		if hasMore {
+			page.NextCursor = last.CursorOf()
+		}
+		if q.After != nil || q.Offset > 0 {
+			page.PrevCursor = first.CursorOf()
+		}
+	}
+	return page, nil
+}
+
+func (s *userService) UpdateUser(
+	ctx context.Context,
+	id, version int,
+	name, email string,
+) (*domain.User, error) {
+	if err := s.authz.Require(ctx, domain.PermissionUsersWrite); err != nil {
+		return nil, err
+	}
+	return s.modify(ctx, id, version, func(u *domain.User) error {
+		if err := u.Update(name, email); err != nil {
+			return errors.Wrap(err, "failed to update user")
+		}
+		return nil
+	})
+}
+
+func (s *userService) PatchUser(
+	ctx context.Context,
+	id, version int,
+	name, email *string,
+) (*domain.User, error) {
+	if err := s.authz.Require(ctx, domain.PermissionUsersWrite); err != nil {
+		return nil, err
+	}
+	return s.modify(ctx, id, version, func(u *domain.User) error {
+		// Absent fields keep their current values (JSON merge-patch semantics).
+		if err := u.Update(shared.ValueOr(name, u.Name), shared.ValueOr(email, u.Email)); err != nil {
+			return errors.Wrap(err, "failed to patch user")
+		}
+		return nil
+	})
+}
+
+func (s *userService) DeleteUser(ctx context.Context, id, version int) error {
+	if err := s.authz.Require(ctx, domain.PermissionUsersDelete); err != nil {
+		return err
+	}
+	// Audit events of the deletion are written in the same transaction.
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id, version); err != nil {
+			return errors.Wrap(err, "failed to delete user")
+		}
+		return nil
+	})
+}
+
+func (s *userService) RestoreUser(ctx context.Context, id int) (*domain.User, error) {
+	if err := s.authz.Require(ctx, domain.PermissionUsersAdmin); err != nil {
+		return nil, err
+	}
+
+	var u *domain.User
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Restore(ctx, id); err != nil {
+			return errors.Wrap(err, "failed to restore user")
+		}
+		restored, err := s.repo.FindByID(ctx, id)
+		if err != nil {
+			return errors.Wrap(err, "failed to get restored user")
+		}
+		u = restored
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// modify loads a user, checks its version, applies change and saves it in one
+// transaction, after checking the resulting email is not taken by another user.
+func (s *userService) modify(
+	ctx context.Context,
+	id, version int,
+	change func(u *domain.User) error,
+) (*domain.User, error) {
+	var u *domain.User
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		found, err := s.repo.FindByID(ctx, id)
+		if err != nil {
+			return errors.Wrap(err, "failed to get user")
+		}
+		if err := found.CheckVersion(version); err != nil {
+			return err
+		}
+		if err := change(found); err != nil {
+			return err
+		}
+
+		existing, err := s.repo.FindByEmail(ctx, found.Email)
+		if err != nil {
+			if !errors.HasCode(err, constants.NotFound) {
+				return errors.Wrap(err, "failed to check existing email")
+			}
+		} else if existing.ID != found.ID {
+			return errors.New(constants.ConstraintError, "duplicate email", nil)
+		}
+
+		if err := s.repo.Save(ctx, found); err != nil {
+			return errors.Wrap(err, "failed to save user")
+		}
+		u = found
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
 }
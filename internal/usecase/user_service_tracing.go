@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wonjinsin/go-boilerplate/internal/domain"
+	"github.com/wonjinsin/go-boilerplate/pkg/tracing"
+)
+
+type tracedUserService struct {
+	next UserService
+}
+
+// NewTracedUserService wraps a UserService so every method call is recorded as a span.
+func NewTracedUserService(next UserService) UserService {
+	return &tracedUserService{next: next}
+}
+
+func (s *tracedUserService) CreateUser(
+	ctx context.Context,
+	name, email, password string,
+) (*domain.User, error) {
+	ctx, span := startUserSpan(ctx, "CreateUser")
+	defer span.End()
+
+	u, err := s.next.CreateUser(ctx, name, email, password)
+	tracing.RecordError(span, err)
+	return u, err
+}
+
+func (s *tracedUserService) GetUser(ctx context.Context, id int) (*domain.User, error) {
+	ctx, span := startUserSpan(ctx, "GetUser", attribute.Int("user.id", id))
+	defer span.End()
+
+	u, err := s.next.GetUser(ctx, id)
+	tracing.RecordError(span, err)
+	return u, err
+}
+
+func (s *tracedUserService) ListUsers(ctx context.Context, q domain.UserListQuery) (*domain.UserPage, error) {
+	ctx, span := startUserSpan(ctx, "ListUsers",
+		attribute.Int("page.limit", q.Limit),
+		attribute.Bool("page.keyset", q.IsKeyset()),
+		attribute.Bool("users.include_deleted", q.IncludeDeleted),
+	)
+	defer span.End()
+
+	page, err := s.next.ListUsers(ctx, q)
+	tracing.RecordError(span, err)
+	return page, err
+}
+
+func (s *tracedUserService) UpdateUser(
+	ctx context.Context,
+	id, version int,
+	name, email string,
+) (*domain.User, error) {
+	ctx, span := startUserSpan(ctx, "UpdateUser", attribute.Int("user.id", id))
+	defer span.End()
+
+	u, err := s.next.UpdateUser(ctx, id, version, name, email)
+	tracing.RecordError(span, err)
+	return u, err
+}
+
+func (s *tracedUserService) PatchUser(
+	ctx context.Context,
+	id, version int,
+	name, email *string,
+) (*domain.User, error) {
+	ctx, span := startUserSpan(ctx, "PatchUser", attribute.Int("user.id", id))
+	defer span.End()
+
+	u, err := s.next.PatchUser(ctx, id, version, name, email)
+	tracing.RecordError(span, err)
+	return u, err
+}
+
+func (s *tracedUserService) DeleteUser(ctx context.Context, id, version int) error {
+	ctx, span := startUserSpan(ctx, "DeleteUser", attribute.Int("user.id", id))
+	defer span.End()
+
+	err := s.next.DeleteUser(ctx, id, version)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (s *tracedUserService) RestoreUser(ctx context.Context, id int) (*domain.User, error) {
+	ctx, span := startUserSpan(ctx, "RestoreUser", attribute.Int("user.id", id))
+	defer span.End()
+
+	u, err := s.next.RestoreUser(ctx, id)
+	tracing.RecordError(span, err)
+	return u, err
+}
+
+// startUserSpan starts an internal span for a UserService method.
+func startUserSpan(ctx context.Context, method string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "UserService."+method, trace.WithAttributes(attrs...))
+}
@@ -0,0 +1,71 @@
+-- Schema matching internal/repository/postgres/dao/schema.
+
+CREATE TABLE users (
+    id            bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    deleted_at    timestamptz NULL,
+    name          varchar NOT NULL,
+    email         varchar NOT NULL,
+    password_hash varchar NULL,
+    created_at    timestamptz NOT NULL
+);
+CREATE INDEX user_created_at ON users (created_at);
+-- Email is unique among live users only, so a deleted user's email can be reused.
+CREATE UNIQUE INDEX user_email ON users (email) WHERE deleted_at IS NULL;
+
+CREATE TABLE refresh_tokens (
+    id         bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    family_id  varchar NOT NULL,
+    token_hash varchar NOT NULL UNIQUE,
+    expires_at timestamptz NOT NULL,
+    revoked_at timestamptz NULL,
+    created_at timestamptz NOT NULL,
+    user_id    bigint NOT NULL,
+    CONSTRAINT refresh_tokens_users_refresh_tokens FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE NO ACTION
+);
+CREATE INDEX refreshtoken_family_id ON refresh_tokens (family_id);
+CREATE INDEX refreshtoken_user_id ON refresh_tokens (user_id);
+
+CREATE TABLE roles (
+    id          bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name        varchar NOT NULL UNIQUE,
+    description varchar NULL,
+    created_at  timestamptz NOT NULL
+);
+
+CREATE TABLE permissions (
+    id          bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name        varchar NOT NULL UNIQUE,
+    description varchar NULL,
+    created_at  timestamptz NOT NULL
+);
+
+CREATE TABLE user_roles (
+    user_id bigint NOT NULL,
+    role_id bigint NOT NULL,
+    PRIMARY KEY (user_id, role_id),
+    CONSTRAINT user_roles_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT user_roles_role_id FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE CASCADE
+);
+
+CREATE TABLE role_permissions (
+    role_id       bigint NOT NULL,
+    permission_id bigint NOT NULL,
+    PRIMARY KEY (role_id, permission_id),
+    CONSTRAINT role_permissions_role_id FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE CASCADE,
+    CONSTRAINT role_permissions_permission_id FOREIGN KEY (permission_id) REFERENCES permissions (id) ON DELETE CASCADE
+);
+
+CREATE TABLE api_keys (
+    id           bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name         varchar NOT NULL,
+    prefix       varchar NOT NULL,
+    key_hash     varchar NOT NULL UNIQUE,
+    scopes       jsonb NOT NULL,
+    expires_at   timestamptz NULL,
+    last_used_at timestamptz NULL,
+    revoked_at   timestamptz NULL,
+    created_at   timestamptz NOT NULL,
+    owner_id     bigint NOT NULL,
+    CONSTRAINT api_keys_users_api_keys FOREIGN KEY (owner_id) REFERENCES users (id) ON DELETE NO ACTION
+);
+CREATE INDEX apikey_owner_id ON api_keys (owner_id);
@@ -0,0 +1,3 @@
+-- role_permissions and user_roles rows are removed by ON DELETE CASCADE.
+DELETE FROM roles WHERE name IN ('admin', 'viewer');
+DELETE FROM permissions WHERE name IN ('users:read', 'users:write', 'users:delete', 'users:admin');
@@ -0,0 +1,17 @@
+-- Built-in permissions (domain.Permission*) and roles.
+
+INSERT INTO permissions (name, description, created_at) VALUES
+    ('users:read', 'Read users', now()),
+    ('users:write', 'Create and update users', now()),
+    ('users:delete', 'Delete users', now()),
+    ('users:admin', 'List deleted users and restore them', now());
+
+INSERT INTO roles (name, description, created_at) VALUES
+    ('admin', 'Full access to users', now()),
+    ('viewer', 'Read-only access to users', now());
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON r.name = 'admin' OR (r.name = 'viewer' AND p.name = 'users:read')
+WHERE r.name IN ('admin', 'viewer');
@@ -0,0 +1,17 @@
+-- Responses of requests sent with an Idempotency-Key, replayed to retries until they expire.
+
+CREATE TABLE idempotency_keys (
+    id                    bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    scope                 varchar NOT NULL,
+    key                   varchar NOT NULL,
+    fingerprint           varchar NOT NULL,
+    status                varchar NOT NULL,
+    response_status       bigint NULL,
+    response_content_type varchar NULL,
+    response_body         bytea NULL,
+    expires_at            timestamptz NOT NULL,
+    created_at            timestamptz NOT NULL
+);
+-- The unique index is the lock: only one request can insert a given key.
+CREATE UNIQUE INDEX idempotencykey_scope_key ON idempotency_keys (scope, key);
+CREATE INDEX idempotencykey_expires_at ON idempotency_keys (expires_at);
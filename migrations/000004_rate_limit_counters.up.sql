@@ -0,0 +1,12 @@
+-- Request counts per client key and fixed window, shared by every server instance.
+
+CREATE TABLE rate_limit_counters (
+    id           bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    key          varchar NOT NULL,
+    window_start timestamptz NOT NULL,
+    count        bigint NOT NULL,
+    expires_at   timestamptz NOT NULL
+);
+-- Increments upsert on this index.
+CREATE UNIQUE INDEX ratelimitcounter_key_window_start ON rate_limit_counters (key, window_start);
+CREATE INDEX ratelimitcounter_expires_at ON rate_limit_counters (expires_at);
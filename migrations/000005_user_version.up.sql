@@ -0,0 +1,3 @@
+-- Version of each user row, incremented by every update, for optimistic concurrency control.
+
+ALTER TABLE users ADD COLUMN version bigint NOT NULL DEFAULT 1;
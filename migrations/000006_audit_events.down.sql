@@ -0,0 +1,3 @@
+-- role_permissions rows are removed by ON DELETE CASCADE.
+DELETE FROM permissions WHERE name = 'audit:read';
+DROP TABLE IF EXISTS audit_events;
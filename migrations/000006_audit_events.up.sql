@@ -0,0 +1,25 @@
+-- Changes of audited entities, written in the transaction of each change.
+
+CREATE TABLE audit_events (
+    id         bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    entity     varchar NOT NULL,
+    entity_id  bigint NOT NULL,
+    operation  varchar NOT NULL,
+    actor      varchar NULL,
+    tr_id      varchar NULL,
+    before     jsonb NULL,
+    after      jsonb NULL,
+    created_at timestamptz NOT NULL
+);
+CREATE INDEX auditevent_entity_entity_id ON audit_events (entity, entity_id);
+CREATE INDEX auditevent_created_at ON audit_events (created_at);
+
+-- Reading the audit log is an admin permission (domain.PermissionAuditRead).
+INSERT INTO permissions (name, description, created_at) VALUES
+    ('audit:read', 'Read the audit log', now());
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.name = 'audit:read'
+WHERE r.name = 'admin';
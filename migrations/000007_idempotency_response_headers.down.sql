@@ -0,0 +1,3 @@
+ALTER TABLE idempotency_keys ADD COLUMN response_content_type varchar NULL;
+UPDATE idempotency_keys SET response_content_type = response_headers ->> 'Content-Type';
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS response_headers;
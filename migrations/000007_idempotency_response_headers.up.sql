@@ -0,0 +1,8 @@
+-- Replay the headers describing a stored response (Content-Type, Location, ETag), not only
+-- its content type.
+
+ALTER TABLE idempotency_keys ADD COLUMN response_headers jsonb NULL;
+UPDATE idempotency_keys
+SET response_headers = jsonb_build_object('Content-Type', response_content_type)
+WHERE response_content_type IS NOT NULL;
+ALTER TABLE idempotency_keys DROP COLUMN response_content_type;
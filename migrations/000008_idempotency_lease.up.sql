@@ -0,0 +1,6 @@
+-- In-progress keys are held by a lease the running request renews, instead of a fixed
+-- lock timeout counted from creation, so a slow request is not taken over by a retry.
+
+ALTER TABLE idempotency_keys ADD COLUMN locked_until timestamptz NULL;
+UPDATE idempotency_keys SET locked_until = created_at;
+ALTER TABLE idempotency_keys ALTER COLUMN locked_until SET NOT NULL;
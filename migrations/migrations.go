@@ -0,0 +1,9 @@
+package migrations
+
+import "embed"
+
+// FS holds the SQL migrations, named <version>_<name>.up.sql and <version>_<name>.down.sql.
+// They are embedded so the migration tool and the server need no files at runtime.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -6,4 +6,6 @@ type ContextKey string
 const (
 	// ContextKeyTrID is the key for storing TrID in context.
 	ContextKeyTrID ContextKey = "tr_id"
+	// ContextKeyErrorFormat is the key for storing the negotiated error format in context.
+	ContextKeyErrorFormat ContextKey = "error_format"
 )
@@ -8,14 +8,59 @@ const (
 	DefaultOffset = 0
 )
 
+// DefaultMaxBodyBytes bounds request bodies unless configured otherwise.
+const DefaultMaxBodyBytes = 1 << 20
+
+// StatusClientClosedRequest is the non-standard status (nginx convention) for requests
+// abandoned by the client before a response was written.
+const StatusClientClosedRequest = 499
+
 // HTTP Headers.
 const (
 	HeaderContentType   = "Content-Type"
 	HeaderAuthorization = "Authorization"
 	HeaderAccept        = "Accept"
+	HeaderWWWAuth       = "WWW-Authenticate"
+	HeaderAPIKey        = "X-API-Key"
+	HeaderRequestID     = "X-Request-ID"
+
+	HeaderLocation    = "Location"
+	HeaderETag        = "ETag"
+	HeaderIfMatch     = "If-Match"
+	HeaderIfNoneMatch = "If-None-Match"
+
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRateLimitPolicy    = "RateLimit-Policy"
+	HeaderRetryAfter         = "Retry-After"
+)
+
+// Authorization schemes.
+const (
+	AuthSchemeBearer = "Bearer"
+	AuthSchemeAPIKey = "ApiKey"
 )
 
 // Content Types.
 const (
+	ContentTypeJSON        = "application/json"
 	ContentTypeJSONCharset = "application/json; charset=utf-8"
+	ContentTypeProblemJSON = "application/problem+json"
+	// ContentTypeMergePatchJSON is the JSON merge patch (RFC 7396) media type.
+	ContentTypeMergePatchJSON = "application/merge-patch+json"
+)
+
+// Error response formats.
+const (
+	// ErrorFormatStandard renders errors in the standard envelope.
+	ErrorFormatStandard = "standard"
+	// ErrorFormatProblem renders errors as RFC 7807 problem details.
+	ErrorFormatProblem = "problem"
 )
+
+// ProblemTypeDefault is the RFC 7807 problem type whose title is the HTTP status text.
+const ProblemTypeDefault = "about:blank"
@@ -6,11 +6,22 @@ const (
 	MaxNameLength  = 200
 	MinEmailLength = 3
 	MaxEmailLength = 320 // RFC 5321 limit.
+
+	// Password validation.
+	MinPasswordLength = 8
+	MaxPasswordLength = 72 // bcrypt only uses the first 72 bytes.
+
+	// Inbound request ID validation.
+	MaxTrIDLength = 128
+
+	// Idempotency key validation.
+	MaxIdempotencyKeyLength = 255
 )
 
 // Regex patterns.
 const (
 	EmailPattern = `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
+	TrIDPattern  = `^[a-zA-Z0-9._:-]+$`
 )
 
 // ID generation.
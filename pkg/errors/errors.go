@@ -8,9 +8,21 @@ import (
 )
 
 // CustomError represents an error with a 4-digit code.
+// Message is the internal description including every wrapped cause, for logs only.
+// Public is the part that is safe to show to clients.
 type CustomError struct {
-	Code    pkgConstants.ErrorCode // 4-digit code (e.g., "0201").
-	Message string
+	Code       pkgConstants.ErrorCode // 4-digit code (e.g., "0201").
+	Message    string
+	Public     string
+	Violations []FieldViolation // Invalid request fields, if any; public like Public.
+	Err        error            // Underlying cause, if any.
+}
+
+// FieldViolation describes one invalid field of a request.
+type FieldViolation struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
 }
 
 // Error implements error interface.
@@ -18,8 +30,13 @@ func (e *CustomError) Error() string {
 	return e.Message
 }
 
+// Unwrap returns the underlying cause so errors.Is and errors.As can inspect it.
+func (e *CustomError) Unwrap() error {
+	return e.Err
+}
+
 // New creates a new CustomError with code and message.
-// If an underlying error is provided, it combines the messages.
+// If an underlying error is provided, it combines the messages; only message is public.
 func New(code pkgConstants.ErrorCode, message string, err error) *CustomError {
 	finalMessage := message
 	if err != nil {
@@ -28,27 +45,46 @@ func New(code pkgConstants.ErrorCode, message string, err error) *CustomError {
 	return &CustomError{
 		Code:    code,
 		Message: finalMessage,
+		Public:  message,
+		Err:     err,
+	}
+}
+
+// NewValidation creates an InvalidParameter error listing every field violation.
+func NewValidation(violations []FieldViolation) *CustomError {
+	const message = "validation failed"
+	return &CustomError{
+		Code:       pkgConstants.InvalidParameter,
+		Message:    fmt.Sprintf("%s: %d invalid field(s)", message, len(violations)),
+		Public:     message,
+		Violations: violations,
 	}
 }
 
 // Wrap wraps an existing error with context.
-// Accepts an optional error code. If provided, uses that code; otherwise preserves existing code or
-// uses InternalError.
+// Accepts an optional error code. If provided, uses that code and makes message public;
+// otherwise preserves existing code and public message or uses InternalError, whose
+// context stays internal.
 func Wrap(err error, message string, code ...pkgConstants.ErrorCode) error {
 	if err == nil {
 		return nil
 	}
 
-	// Determine which code to use.
+	// Determine which code and public message to use.
 	var finalCode pkgConstants.ErrorCode
+	var public string
+	var violations []FieldViolation
 	if len(code) > 0 && code[0] != "" {
 		// Use provided code.
 		finalCode = code[0]
+		public = message
 	} else {
 		// If already CustomError, preserve its code.
 		var customErr *CustomError
 		if errors.As(err, &customErr) {
 			finalCode = customErr.Code
+			public = customErr.Public
+			violations = customErr.Violations
 		} else {
 			// Otherwise use generic internal error.
 			finalCode = pkgConstants.InternalError
@@ -56,8 +92,11 @@ func Wrap(err error, message string, code ...pkgConstants.ErrorCode) error {
 	}
 
 	return &CustomError{
-		Code:    finalCode,
-		Message: fmt.Sprintf("%s: %s", message, err.Error()),
+		Code:       finalCode,
+		Message:    fmt.Sprintf("%s: %s", message, err.Error()),
+		Public:     public,
+		Violations: violations,
+		Err:        err,
 	}
 }
 
@@ -70,6 +109,25 @@ func GetCode(err error) pkgConstants.ErrorCode {
 	return pkgConstants.UnknownError
 }
 
+// PublicMessage extracts the client-safe message from CustomError.
+// It is empty for other errors, whose details must not reach clients.
+func PublicMessage(err error) string {
+	var customErr *CustomError
+	if errors.As(err, &customErr) {
+		return customErr.Public
+	}
+	return ""
+}
+
+// GetViolations extracts the field violations from CustomError.
+func GetViolations(err error) []FieldViolation {
+	var customErr *CustomError
+	if errors.As(err, &customErr) {
+		return customErr.Violations
+	}
+	return nil
+}
+
 // HasCode checks if error has specific code.
 func HasCode(err error, code pkgConstants.ErrorCode) bool {
 	return GetCode(err) == code
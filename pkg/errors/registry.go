@@ -0,0 +1,60 @@
+package errors
+
+import (
+	"net/http"
+	"sync"
+
+	pkgConstants "github.com/wonjinsin/go-boilerplate/internal/constants"
+	"github.com/wonjinsin/go-boilerplate/pkg/constants"
+)
+
+// Level is the severity an error is logged with.
+type Level int
+
+const (
+	// LevelWarn is for errors caused by the client, such as invalid input.
+	LevelWarn Level = iota
+	// LevelError is for errors that need operator attention.
+	LevelError
+)
+
+// Mapping describes how errors with a code are reported over HTTP.
+type Mapping struct {
+	Status int
+	Level  Level
+}
+
+var (
+	mappingsMu sync.RWMutex
+	mappings   = map[pkgConstants.ErrorCode]Mapping{
+		pkgConstants.InvalidParameter: {Status: http.StatusBadRequest, Level: LevelWarn},
+		pkgConstants.Unauthorized:     {Status: http.StatusUnauthorized, Level: LevelWarn},
+		pkgConstants.Forbidden:        {Status: http.StatusForbidden, Level: LevelWarn},
+		pkgConstants.NotFound:         {Status: http.StatusNotFound, Level: LevelWarn},
+		pkgConstants.ConstraintError:  {Status: http.StatusConflict, Level: LevelWarn},
+		pkgConstants.PreconditionFail: {Status: http.StatusPreconditionFailed, Level: LevelWarn},
+		pkgConstants.PayloadTooLarge:  {Status: http.StatusRequestEntityTooLarge, Level: LevelWarn},
+		pkgConstants.UnsupportedMedia: {Status: http.StatusUnsupportedMediaType, Level: LevelWarn},
+		pkgConstants.Unprocessable:    {Status: http.StatusUnprocessableEntity, Level: LevelWarn},
+		pkgConstants.PreconditionReq:  {Status: http.StatusPreconditionRequired, Level: LevelWarn},
+		pkgConstants.TooManyRequests:  {Status: http.StatusTooManyRequests, Level: LevelWarn},
+		pkgConstants.Canceled:         {Status: constants.StatusClientClosedRequest, Level: LevelWarn},
+		pkgConstants.InternalError:    {Status: http.StatusInternalServerError, Level: LevelError},
+		pkgConstants.Timeout:          {Status: http.StatusGatewayTimeout, Level: LevelError},
+	}
+)
+
+// Register sets the mapping of code, adding a new code or overriding a built-in one.
+func Register(code pkgConstants.ErrorCode, m Mapping) {
+	mappingsMu.Lock()
+	defer mappingsMu.Unlock()
+	mappings[code] = m
+}
+
+// Lookup returns the mapping of code and whether it is registered.
+func Lookup(code pkgConstants.ErrorCode) (Mapping, bool) {
+	mappingsMu.RLock()
+	defer mappingsMu.RUnlock()
+	m, ok := mappings[code]
+	return m, ok
+}
@@ -0,0 +1,134 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Status is the state of a component or of the whole service.
+type Status string
+
+// Statuses reported by Readiness.
+const (
+	StatusUp       Status = "up"
+	StatusDown     Status = "down"
+	StatusDraining Status = "draining"
+)
+
+// CheckFunc reports whether a dependency is usable; a nil error means up.
+type CheckFunc func(ctx context.Context) error
+
+// Result is the outcome of one check.
+type Result struct {
+	Status    Status
+	Err       error
+	CheckedAt time.Time
+}
+
+// Report is the outcome of all registered checks.
+type Report struct {
+	Status     Status
+	Components map[string]Result
+}
+
+// Ready reports whether the service should receive traffic.
+func (r Report) Ready() bool {
+	return r.Status == StatusUp
+}
+
+// check is a registered checker with its cached result.
+type check struct {
+	name string
+	fn   CheckFunc
+
+	mu     sync.Mutex
+	result Result
+}
+
+// Readiness runs registered dependency checks. Checks run concurrently, each bounded by
+// a timeout, and their results are cached so frequent probes do not load dependencies.
+type Readiness struct {
+	timeout  time.Duration
+	cacheTTL time.Duration
+
+	mu       sync.RWMutex
+	checks   []*check
+	draining atomic.Bool
+}
+
+// NewReadiness creates a Readiness with the given per-check timeout and result cache TTL.
+func NewReadiness(timeout, cacheTTL time.Duration) *Readiness {
+	return &Readiness{timeout: timeout, cacheTTL: cacheTTL}
+}
+
+// Register adds a named check.
+func (r *Readiness) Register(name string, fn CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, &check{name: name, fn: fn})
+}
+
+// Drain marks the service as shutting down. Every later report is StatusDraining, so load
+// balancers stop routing traffic before the server stops accepting it.
+func (r *Readiness) Drain() {
+	r.draining.Store(true)
+}
+
+// Check runs the registered checks, reusing results younger than the cache TTL.
+func (r *Readiness) Check(ctx context.Context) Report {
+	if r.draining.Load() {
+		return Report{Status: StatusDraining}
+	}
+
+	r.mu.RLock()
+	checks := r.checks
+	r.mu.RUnlock()
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = r.run(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Components: make(map[string]Result, len(checks))}
+	for i, c := range checks {
+		report.Components[c.name] = results[i]
+		if results[i].Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+// run returns the cached result of c or runs it. Concurrent probes wait for a single run.
+func (r *Readiness) run(ctx context.Context, c *check) Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.result.CheckedAt.IsZero() && time.Since(c.result.CheckedAt) < r.cacheTTL {
+		return c.result
+	}
+
+	// The result is shared with other probes, so it must not depend on this caller going away.
+	ctx = context.WithoutCancel(ctx)
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	result := Result{Status: StatusUp, CheckedAt: time.Now()}
+	if err := c.fn(ctx); err != nil {
+		result.Status = StatusDown
+		result.Err = err
+	}
+	c.result = result
+	return result
+}
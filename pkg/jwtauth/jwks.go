@@ -0,0 +1,191 @@
+package jwtauth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+)
+
+// jwk is a single JSON Web Key (RFC 7517) of type RSA, OKP (Ed25519) or oct.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	K   string `json:"k"`
+}
+
+// jwkSet is a JSON Web Key Set document.
+type jwkSet struct {
+	Keys []jwk `json:"keys"`
+}
+
+// jwksKey is a parsed key along with the algorithm it is restricted to.
+type jwksKey struct {
+	alg string
+	key any
+}
+
+// JWKSFile serves keys from a local JWKS file, selected by kid.
+// The file is re-read when its modification time changes, so keys can be rotated
+// by rewriting the file without restarting the server.
+type JWKSFile struct {
+	path          string
+	checkInterval time.Duration
+
+	mu        sync.RWMutex
+	keys      map[string]jwksKey
+	modTime   time.Time
+	lastCheck time.Time
+}
+
+// NewJWKSFile loads the JWKS file at path. The file's modification time is checked
+// for changes at most once per checkInterval.
+func NewJWKSFile(path string, checkInterval time.Duration) (*JWKSFile, error) {
+	s := &JWKSFile{path: path, checkInterval: checkInterval}
+	if err := s.reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// VerificationKey implements KeySource.
+func (s *JWKSFile) VerificationKey(_ context.Context, kid, alg string) (any, error) {
+	s.refreshIfChanged()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if kid == "" {
+		return nil, fmt.Errorf("token has no kid header")
+	}
+	k, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown kid %q", kid)
+	}
+	if k.alg != alg {
+		return nil, fmt.Errorf("kid %q is not valid for algorithm %s", kid, alg)
+	}
+	return k.key, nil
+}
+
+// Algorithms implements KeySource.
+func (s *JWKSFile) Algorithms() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := map[string]bool{}
+	var algs []string
+	for _, k := range s.keys {
+		if !seen[k.alg] {
+			seen[k.alg] = true
+			algs = append(algs, k.alg)
+		}
+	}
+	return algs
+}
+
+// refreshIfChanged reloads the file when its modification time has changed.
+// Reload failures keep the previously loaded keys in place.
+func (s *JWKSFile) refreshIfChanged() {
+	s.mu.RLock()
+	due := time.Since(s.lastCheck) >= s.checkInterval
+	s.mu.RUnlock()
+	if !due {
+		return
+	}
+
+	info, err := os.Stat(s.path)
+
+	s.mu.Lock()
+	s.lastCheck = time.Now()
+	changed := err == nil && !info.ModTime().Equal(s.modTime)
+	s.mu.Unlock()
+
+	if changed {
+		_ = s.reload()
+	}
+}
+
+// reload reads and parses the JWKS file, replacing the current key set.
+func (s *JWKSFile) reload() error {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to stat jwks file: %w", err)
+	}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to read jwks file: %w", err)
+	}
+
+	var set jwkSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("failed to parse jwks file: %w", err)
+	}
+
+	keys := make(map[string]jwksKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		parsed, err := parseJWK(k)
+		if err != nil {
+			return fmt.Errorf("invalid key %q in jwks file: %w", k.Kid, err)
+		}
+		keys[k.Kid] = parsed
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.modTime = info.ModTime()
+	s.lastCheck = time.Now()
+	s.mu.Unlock()
+	return nil
+}
+
+// parseJWK converts a JWK into a verification key.
+func parseJWK(k jwk) (jwksKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return jwksKey{}, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return jwksKey{}, fmt.Errorf("invalid exponent: %w", err)
+		}
+		return jwksKey{alg: AlgRS256, key: &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return jwksKey{}, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return jwksKey{}, fmt.Errorf("invalid ed25519 public key")
+		}
+		return jwksKey{alg: AlgEdDSA, key: ed25519.PublicKey(x)}, nil
+	case "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil || len(secret) == 0 {
+			return jwksKey{}, fmt.Errorf("invalid symmetric key")
+		}
+		return jwksKey{alg: AlgHS256, key: secret}, nil
+	default:
+		return jwksKey{}, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
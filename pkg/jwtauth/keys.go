@@ -0,0 +1,137 @@
+package jwtauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported signing algorithms.
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+// KeySource resolves the key used to verify a token signed with alg,
+// optionally identified by the kid header.
+type KeySource interface {
+	VerificationKey(ctx context.Context, kid, alg string) (any, error)
+	// Algorithms lists the algorithms this source can provide keys for.
+	Algorithms() []string
+}
+
+// StaticKeySource serves keys configured up front: an HMAC secret and/or a public key.
+// It ignores kid, so it suits deployments with a single active key per algorithm.
+type StaticKeySource struct {
+	hmacSecret []byte
+	publicKey  crypto.PublicKey
+}
+
+// NewStaticKeySource creates a key source from an HMAC secret and an RSA or Ed25519
+// public key. Either may be empty.
+func NewStaticKeySource(hmacSecret []byte, publicKey crypto.PublicKey) *StaticKeySource {
+	return &StaticKeySource{hmacSecret: hmacSecret, publicKey: publicKey}
+}
+
+// VerificationKey implements KeySource.
+func (s *StaticKeySource) VerificationKey(_ context.Context, _, alg string) (any, error) {
+	switch alg {
+	case AlgHS256:
+		if len(s.hmacSecret) > 0 {
+			return s.hmacSecret, nil
+		}
+	case AlgRS256:
+		if key, ok := s.publicKey.(*rsa.PublicKey); ok {
+			return key, nil
+		}
+	case AlgEdDSA:
+		if key, ok := s.publicKey.(ed25519.PublicKey); ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("no key configured for algorithm %s", alg)
+}
+
+// Algorithms implements KeySource.
+func (s *StaticKeySource) Algorithms() []string {
+	var algs []string
+	if len(s.hmacSecret) > 0 {
+		algs = append(algs, AlgHS256)
+	}
+	switch s.publicKey.(type) {
+	case *rsa.PublicKey:
+		algs = append(algs, AlgRS256)
+	case ed25519.PublicKey:
+		algs = append(algs, AlgEdDSA)
+	}
+	return algs
+}
+
+// KeySources tries each source in order and returns the first key found.
+type KeySources []KeySource
+
+// VerificationKey implements KeySource.
+func (s KeySources) VerificationKey(ctx context.Context, kid, alg string) (any, error) {
+	var errs []string
+	for _, src := range s {
+		key, err := src.VerificationKey(ctx, kid, alg)
+		if err == nil {
+			return key, nil
+		}
+		errs = append(errs, err.Error())
+	}
+	return nil, fmt.Errorf("no verification key found: %s", strings.Join(errs, "; "))
+}
+
+// Algorithms implements KeySource.
+func (s KeySources) Algorithms() []string {
+	seen := map[string]bool{}
+	var algs []string
+	for _, src := range s {
+		for _, alg := range src.Algorithms() {
+			if !seen[alg] {
+				seen[alg] = true
+				algs = append(algs, alg)
+			}
+		}
+	}
+	return algs
+}
+
+// LoadPublicKeyPEM reads a PEM-encoded PKIX RSA or Ed25519 public key from path.
+func LoadPublicKeyPEM(path string) (crypto.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in %s", path)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		// Fall back to PKCS#1, the other common encoding for RSA public keys.
+		if rsaKey, rsaErr := jwt.ParseRSAPublicKeyFromPEM(data); rsaErr == nil {
+			return rsaKey, nil
+		}
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	switch key.(type) {
+	case *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+}
@@ -0,0 +1,96 @@
+package jwtauth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SignerConfig holds the claims stamped on issued tokens.
+type SignerConfig struct {
+	Issuer   string
+	Audience string
+	KeyID    string
+	TTL      time.Duration
+}
+
+// Signer issues signed access tokens.
+type Signer struct {
+	method jwt.SigningMethod
+	key    any
+	cfg    SignerConfig
+}
+
+// NewHMACSigner creates an HS256 signer.
+func NewHMACSigner(secret []byte, cfg SignerConfig) *Signer {
+	return &Signer{method: jwt.SigningMethodHS256, key: secret, cfg: cfg}
+}
+
+// NewPrivateKeySigner creates an RS256 or EdDSA signer depending on the key type.
+func NewPrivateKeySigner(key crypto.PrivateKey, cfg SignerConfig) (*Signer, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return &Signer{method: jwt.SigningMethodRS256, key: k, cfg: cfg}, nil
+	case ed25519.PrivateKey:
+		return &Signer{method: jwt.SigningMethodEdDSA, key: k, cfg: cfg}, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+}
+
+// Issue signs a token for subject that expires after the configured TTL.
+func (s *Signer) Issue(subject string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(s.cfg.TTL)
+
+	claims := Claims{
+		Subject:   subject,
+		Issuer:    s.cfg.Issuer,
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+	if s.cfg.Audience != "" {
+		claims.Audience = jwt.ClaimStrings{s.cfg.Audience}
+	}
+
+	token := jwt.NewWithClaims(s.method, claims)
+	if s.cfg.KeyID != "" {
+		token.Header["kid"] = s.cfg.KeyID
+	}
+
+	signed, err := token.SignedString(s.key)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign token: %w", err)
+	}
+	return signed, expiresAt, nil
+}
+
+// LoadPrivateKeyPEM reads a PEM-encoded PKCS#8 (RSA or Ed25519) or PKCS#1 (RSA) private key.
+func LoadPrivateKeyPEM(path string) (crypto.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in %s", path)
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,57 @@
+package jwtauth
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims are the registered JWT claims accepted by the verifier.
+type Claims = jwt.RegisteredClaims
+
+// VerifierConfig holds the claim validation rules.
+type VerifierConfig struct {
+	Issuer    string
+	Audience  string
+	ClockSkew time.Duration
+}
+
+// Verifier validates signed JWTs against a key source.
+type Verifier struct {
+	keys KeySource
+	cfg  VerifierConfig
+	now  func() time.Time
+}
+
+// NewVerifier creates a verifier that accepts the algorithms provided by keys.
+func NewVerifier(keys KeySource, cfg VerifierConfig) *Verifier {
+	return &Verifier{keys: keys, cfg: cfg, now: time.Now}
+}
+
+// Verify checks the token signature, expiry, not-before, issuer and audience,
+// allowing the configured clock skew, and returns its claims.
+func (v *Verifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(v.keys.Algorithms()),
+		jwt.WithLeeway(v.cfg.ClockSkew),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(v.now),
+	}
+	if v.cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(v.cfg.Issuer))
+	}
+	if v.cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(v.cfg.Audience))
+	}
+
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.keys.VerificationKey(ctx, kid, t.Method.Alg())
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
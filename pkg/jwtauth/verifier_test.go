@@ -0,0 +1,199 @@
+package jwtauth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestVerifierVerify(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	secret := []byte("test-secret-of-at-least-32-bytes!")
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaPrivate, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaPublicDER, err := x509.MarshalPKIXPublicKey(&rsaPrivate.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := VerifierConfig{Issuer: "issuer", Audience: "audience", ClockSkew: 30 * time.Second}
+	claims := func(edit func(c *Claims)) *Claims {
+		c := &Claims{
+			Subject:   "1",
+			Issuer:    "issuer",
+			Audience:  jwt.ClaimStrings{"audience"},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+		}
+		if edit != nil {
+			edit(c)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		keys    KeySource
+		method  jwt.SigningMethod
+		signKey any
+		claims  *Claims
+		wantErr bool
+	}{
+		{
+			name:    "valid HS256",
+			keys:    NewStaticKeySource(secret, nil),
+			method:  jwt.SigningMethodHS256,
+			signKey: secret,
+			claims:  claims(nil),
+		},
+		{
+			name:    "valid EdDSA",
+			keys:    NewStaticKeySource(nil, edPublic),
+			method:  jwt.SigningMethodEdDSA,
+			signKey: edPrivate,
+			claims:  claims(nil),
+		},
+		{
+			name:    "valid RS256",
+			keys:    NewStaticKeySource(nil, &rsaPrivate.PublicKey),
+			method:  jwt.SigningMethodRS256,
+			signKey: rsaPrivate,
+			claims:  claims(nil),
+		},
+		{
+			name:    "algorithm without a configured key",
+			keys:    NewStaticKeySource(secret, nil),
+			method:  jwt.SigningMethodEdDSA,
+			signKey: edPrivate,
+			claims:  claims(nil),
+			wantErr: true,
+		},
+		{
+			name:    "unsupported HMAC variant",
+			keys:    NewStaticKeySource(secret, nil),
+			method:  jwt.SigningMethodHS512,
+			signKey: secret,
+			claims:  claims(nil),
+			wantErr: true,
+		},
+		{
+			name:    "HMAC signed with the RSA public key",
+			keys:    NewStaticKeySource(nil, &rsaPrivate.PublicKey),
+			method:  jwt.SigningMethodHS256,
+			signKey: rsaPublicDER,
+			claims:  claims(nil),
+			wantErr: true,
+		},
+		{
+			name:    "unsigned token",
+			keys:    NewStaticKeySource(secret, nil),
+			method:  jwt.SigningMethodNone,
+			signKey: jwt.UnsafeAllowNoneSignatureType,
+			claims:  claims(nil),
+			wantErr: true,
+		},
+		{
+			name:    "wrong secret",
+			keys:    NewStaticKeySource(secret, nil),
+			method:  jwt.SigningMethodHS256,
+			signKey: []byte("another-secret-of-at-least-32-bytes"),
+			claims:  claims(nil),
+			wantErr: true,
+		},
+		{
+			name:    "expired within clock skew",
+			keys:    NewStaticKeySource(secret, nil),
+			method:  jwt.SigningMethodHS256,
+			signKey: secret,
+			claims: claims(func(c *Claims) {
+				c.ExpiresAt = jwt.NewNumericDate(now.Add(-29 * time.Second))
+			}),
+		},
+		{
+			name:    "expired beyond clock skew",
+			keys:    NewStaticKeySource(secret, nil),
+			method:  jwt.SigningMethodHS256,
+			signKey: secret,
+			claims: claims(func(c *Claims) {
+				c.ExpiresAt = jwt.NewNumericDate(now.Add(-31 * time.Second))
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "not yet valid within clock skew",
+			keys:    NewStaticKeySource(secret, nil),
+			method:  jwt.SigningMethodHS256,
+			signKey: secret,
+			claims: claims(func(c *Claims) {
+				c.NotBefore = jwt.NewNumericDate(now.Add(29 * time.Second))
+			}),
+		},
+		{
+			name:    "not yet valid beyond clock skew",
+			keys:    NewStaticKeySource(secret, nil),
+			method:  jwt.SigningMethodHS256,
+			signKey: secret,
+			claims: claims(func(c *Claims) {
+				c.NotBefore = jwt.NewNumericDate(now.Add(31 * time.Second))
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "missing expiry",
+			keys:    NewStaticKeySource(secret, nil),
+			method:  jwt.SigningMethodHS256,
+			signKey: secret,
+			claims:  claims(func(c *Claims) { c.ExpiresAt = nil }),
+			wantErr: true,
+		},
+		{
+			name:    "wrong issuer",
+			keys:    NewStaticKeySource(secret, nil),
+			method:  jwt.SigningMethodHS256,
+			signKey: secret,
+			claims:  claims(func(c *Claims) { c.Issuer = "other" }),
+			wantErr: true,
+		},
+		{
+			name:    "wrong audience",
+			keys:    NewStaticKeySource(secret, nil),
+			method:  jwt.SigningMethodHS256,
+			signKey: secret,
+			claims:  claims(func(c *Claims) { c.Audience = jwt.ClaimStrings{"other"} }),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := jwt.NewWithClaims(tt.method, tt.claims).SignedString(tt.signKey)
+			if err != nil {
+				t.Fatalf("failed to sign token: %v", err)
+			}
+
+			v := NewVerifier(tt.keys, cfg)
+			v.now = func() time.Time { return now }
+
+			got, err := v.Verify(context.Background(), token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Subject != "1" {
+				t.Errorf("Verify() subject = %q, want %q", got.Subject, "1")
+			}
+		})
+	}
+}
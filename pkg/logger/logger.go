@@ -8,6 +8,7 @@ import (
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/wonjinsin/go-boilerplate/pkg/constants"
 )
@@ -54,68 +55,34 @@ func GetTrIDFromContext(ctx context.Context) string {
 	return ""
 }
 
-// LogError logs an error with TrID from context.
+// LogError logs an error with TrID and trace IDs from context.
 func LogError(ctx context.Context, msg string, err error) {
-	trID := GetTrIDFromContext(ctx)
-	if trID != "" {
-		log.Error().
-			Str("trid", trID).
-			Err(err).
-			Msg(msg)
-	} else {
-		log.Error().
-			Err(err).
-			Msg(msg)
-	}
+	withContext(ctx, log.Error()).
+		Err(err).
+		Msg(msg)
 }
 
-// LogInfo logs an info message with TrID from context.
+// LogInfo logs an info message with TrID and trace IDs from context.
 func LogInfo(ctx context.Context, msg string) {
-	trID := GetTrIDFromContext(ctx)
-	if trID != "" {
-		log.Info().
-			Str("trid", trID).
-			Msg(msg)
-	} else {
-		log.Info().
-			Msg(msg)
-	}
+	withContext(ctx, log.Info()).
+		Msg(msg)
 }
 
-// LogWarn logs a warning message with TrID from context.
+// LogWarn logs a warning message with TrID and trace IDs from context.
 func LogWarn(ctx context.Context, msg string) {
-	trID := GetTrIDFromContext(ctx)
-	if trID != "" {
-		log.Warn().
-			Str("trid", trID).
-			Msg(msg)
-	} else {
-		log.Warn().
-			Msg(msg)
-	}
+	withContext(ctx, log.Warn()).
+		Msg(msg)
 }
 
-// LogDebug logs a debug message with TrID from context.
+// LogDebug logs a debug message with TrID and trace IDs from context.
 func LogDebug(ctx context.Context, msg string) {
-	trID := GetTrIDFromContext(ctx)
-	if trID != "" {
-		log.Debug().
-			Str("trid", trID).
-			Msg(msg)
-	} else {
-		log.Debug().
-			Msg(msg)
-	}
+	withContext(ctx, log.Debug()).
+		Msg(msg)
 }
 
-// WithFields returns a logger with additional fields and TrID from context.
+// WithFields returns a logger with additional fields, TrID and trace IDs from context.
 func WithFields(ctx context.Context, fields map[string]interface{}) *zerolog.Event {
-	trID := GetTrIDFromContext(ctx)
-	event := log.Info()
-
-	if trID != "" {
-		event = event.Str("trid", trID)
-	}
+	event := withContext(ctx, log.Info())
 
 	for k, v := range fields {
 		event = event.Interface(k, v)
@@ -123,3 +90,20 @@ func WithFields(ctx context.Context, fields map[string]interface{}) *zerolog.Eve
 
 	return event
 }
+
+// withContext adds the TrID and, when the context carries a span, the OpenTelemetry
+// trace and span IDs to the event.
+func withContext(ctx context.Context, event *zerolog.Event) *zerolog.Event {
+	if ctx == nil {
+		return event
+	}
+	if trID := GetTrIDFromContext(ctx); trID != "" {
+		event = event.Str("trid", trID)
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		event = event.
+			Str("trace_id", sc.TraceID().String()).
+			Str("span_id", sc.SpanID().String())
+	}
+	return event
+}
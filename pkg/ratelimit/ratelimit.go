@@ -0,0 +1,42 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Stores a Limiter can be backed by.
+const (
+	// StoreMemory keeps token buckets in process memory; limits are per instance.
+	StoreMemory = "memory"
+	// StorePostgres keeps sliding-window counters in PostgreSQL; limits are shared by
+	// every instance.
+	StorePostgres = "postgres"
+)
+
+// Limit allows Requests requests per Window. A limit with no requests is disabled.
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// Enabled reports whether the limit restricts anything.
+func (l Limit) Enabled() bool {
+	return l.Requests > 0 && l.Window > 0
+}
+
+// Result is the outcome of one request against a limit.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is how long until the full limit is available again.
+	Reset time.Duration
+	// RetryAfter is how long a denied caller should wait; zero when allowed.
+	RetryAfter time.Duration
+}
+
+// Limiter counts a request of key against limit.
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// WindowCounter stores request counts per key and fixed window.
+type WindowCounter interface {
+	// Increment adds one to the count of key in the window starting at start and
+	// returns the new count. The count may be dropped after expiresAt.
+	Increment(ctx context.Context, key string, start, expiresAt time.Time) (int, error)
+	// Count returns the count of key in the window starting at start, zero when absent.
+	Count(ctx context.Context, key string, start time.Time) (int, error)
+}
+
+// SlidingWindow is a Limiter over shared counters. It weights the count of the
+// previous fixed window by how much of it still overlaps the sliding window, which
+// approximates a true sliding log with two counters per key.
+type SlidingWindow struct {
+	counters WindowCounter
+	now      func() time.Time
+}
+
+// NewSlidingWindow creates a limiter storing its counts in counters.
+func NewSlidingWindow(counters WindowCounter) *SlidingWindow {
+	return &SlidingWindow{counters: counters, now: time.Now}
+}
+
+// Allow counts a request of key. Denied requests are counted too, so callers that
+// keep retrying stay limited.
+func (s *SlidingWindow) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	if !limit.Enabled() {
+		return Result{Allowed: true}, nil
+	}
+
+	now := s.now()
+	start := now.Truncate(limit.Window)
+	end := start.Add(limit.Window)
+
+	current, err := s.counters.Increment(ctx, key, start, end.Add(limit.Window))
+	if err != nil {
+		return Result{}, err
+	}
+	previous, err := s.counters.Count(ctx, key, start.Add(-limit.Window))
+	if err != nil {
+		return Result{}, err
+	}
+
+	overlap := 1 - float64(now.Sub(start))/float64(limit.Window)
+	used := int(math.Ceil(float64(previous)*overlap)) + current
+
+	res := Result{
+		Allowed:   used <= limit.Requests,
+		Limit:     limit.Requests,
+		Remaining: max(0, limit.Requests-used),
+		Reset:     end.Sub(now),
+	}
+	if !res.Allowed {
+		res.RetryAfter = res.Reset
+	}
+	return res, nil
+}
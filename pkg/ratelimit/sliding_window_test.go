@@ -0,0 +1,150 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeCounter is an in-memory WindowCounter.
+type fakeCounter struct {
+	counts map[time.Time]int
+	err    error
+}
+
+func (f *fakeCounter) Increment(_ context.Context, _ string, start, _ time.Time) (int, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	f.counts[start]++
+	return f.counts[start], nil
+}
+
+func (f *fakeCounter) Count(_ context.Context, _ string, start time.Time) (int, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	return f.counts[start], nil
+}
+
+func TestSlidingWindowAllow(t *testing.T) {
+	limit := Limit{Requests: 10, Window: time.Minute}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	previousStart := start.Add(-time.Minute)
+
+	tests := []struct {
+		name string
+		// previous and current are the counts stored before the request.
+		previous int
+		current  int
+		elapsed  time.Duration
+		limit    Limit
+		want     Result
+	}{
+		{
+			name:    "empty windows",
+			elapsed: 15 * time.Second,
+			limit:   limit,
+			want:    Result{Allowed: true, Limit: 10, Remaining: 9, Reset: 45 * time.Second},
+		},
+		{
+			name:     "previous window fully weighted at its end",
+			previous: 9,
+			limit:    limit,
+			want:     Result{Allowed: true, Limit: 10, Remaining: 0, Reset: time.Minute},
+		},
+		{
+			name:     "previous window weighted by overlap",
+			previous: 8,
+			current:  3,
+			elapsed:  15 * time.Second,
+			limit:    limit,
+			// ceil(8 * 0.75) + 4
+			want: Result{Allowed: true, Limit: 10, Remaining: 0, Reset: 45 * time.Second},
+		},
+		{
+			name:     "over the limit",
+			previous: 8,
+			current:  4,
+			elapsed:  15 * time.Second,
+			limit:    limit,
+			// ceil(8 * 0.75) + 5
+			want: Result{Limit: 10, Remaining: 0, Reset: 45 * time.Second, RetryAfter: 45 * time.Second},
+		},
+		{
+			name:     "less overlap frees capacity",
+			previous: 8,
+			current:  4,
+			elapsed:  45 * time.Second,
+			limit:    limit,
+			// ceil(8 * 0.25) + 5
+			want: Result{Allowed: true, Limit: 10, Remaining: 3, Reset: 15 * time.Second},
+		},
+		{
+			name:     "weighted count rounds up",
+			previous: 1,
+			elapsed:  59 * time.Second,
+			limit:    limit,
+			// ceil(1 * 1/60) + 1
+			want: Result{Allowed: true, Limit: 10, Remaining: 8, Reset: time.Second},
+		},
+		{
+			name:    "current window alone",
+			current: 10,
+			elapsed: 30 * time.Second,
+			limit:   limit,
+			want:    Result{Limit: 10, Remaining: 0, Reset: 30 * time.Second, RetryAfter: 30 * time.Second},
+		},
+		{
+			name:    "disabled limit allows everything",
+			current: 100,
+			limit:   Limit{Window: time.Minute},
+			want:    Result{Allowed: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter := &fakeCounter{counts: map[time.Time]int{
+				previousStart: tt.previous,
+				start:         tt.current,
+			}}
+			sw := NewSlidingWindow(counter)
+			sw.now = func() time.Time { return start.Add(tt.elapsed) }
+
+			got, err := sw.Allow(context.Background(), "k", tt.limit)
+			if err != nil {
+				t.Fatalf("Allow() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Allow() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlidingWindowCountsDeniedRequests(t *testing.T) {
+	limit := Limit{Requests: 1, Window: time.Minute}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	counter := &fakeCounter{counts: map[time.Time]int{}}
+	sw := NewSlidingWindow(counter)
+	sw.now = func() time.Time { return start }
+
+	for range 3 {
+		_, _ = sw.Allow(context.Background(), "k", limit)
+	}
+	if got := counter.counts[start]; got != 3 {
+		t.Errorf("current count = %d, want 3", got)
+	}
+}
+
+func TestSlidingWindowCounterError(t *testing.T) {
+	errStore := errors.New("store down")
+	sw := NewSlidingWindow(&fakeCounter{err: errStore})
+
+	_, err := sw.Allow(context.Background(), "k", Limit{Requests: 1, Window: time.Minute})
+	if !errors.Is(err, errStore) {
+		t.Errorf("Allow() error = %v, want %v", err, errStore)
+	}
+}
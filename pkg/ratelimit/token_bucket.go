@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often idle buckets are dropped.
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	window time.Duration
+}
+
+// TokenBucket is an in-memory Limiter. Each key has a bucket holding up to
+// limit.Requests tokens that refills evenly over limit.Window, so short bursts are
+// allowed while the average rate is bounded.
+type TokenBucket struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewTokenBucket creates an empty in-memory limiter.
+func NewTokenBucket() *TokenBucket {
+	return &TokenBucket{buckets: make(map[string]*bucket), now: time.Now}
+}
+
+// Allow takes a token from the bucket of key.
+func (t *TokenBucket) Allow(_ context.Context, key string, limit Limit) (Result, error) {
+	if !limit.Enabled() {
+		return Result{Allowed: true}, nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	t.sweep(now)
+
+	capacity := float64(limit.Requests)
+	perToken := limit.Window / time.Duration(limit.Requests)
+
+	b, ok := t.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, last: now}
+		t.buckets[key] = b
+	}
+	b.window = limit.Window
+	b.tokens = math.Min(capacity, b.tokens+float64(now.Sub(b.last))/float64(perToken))
+	b.last = now
+
+	res := Result{Limit: limit.Requests}
+	if b.tokens >= 1 {
+		b.tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = time.Duration((1 - b.tokens) * float64(perToken))
+	}
+	res.Remaining = int(b.tokens)
+	res.Reset = time.Duration((capacity - b.tokens) * float64(perToken))
+	return res, nil
+}
+
+// sweep drops buckets idle for a whole window; they have refilled and are
+// indistinguishable from new ones.
+func (t *TokenBucket) sweep(now time.Time) {
+	if now.Sub(t.lastSweep) < sweepInterval {
+		return
+	}
+	t.lastSweep = now
+	for key, b := range t.buckets {
+		if now.Sub(b.last) >= b.window {
+			delete(t.buckets, key)
+		}
+	}
+}
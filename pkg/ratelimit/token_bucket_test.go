@@ -0,0 +1,137 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketAllow(t *testing.T) {
+	// One token per second.
+	limit := Limit{Requests: 10, Window: 10 * time.Second}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		// before requests are made at start, then the checked one after advance.
+		before  int
+		advance time.Duration
+		limit   Limit
+		want    Result
+	}{
+		{
+			name:  "new key starts full",
+			limit: limit,
+			want:  Result{Allowed: true, Limit: 10, Remaining: 9, Reset: time.Second},
+		},
+		{
+			name:   "last token",
+			before: 9,
+			limit:  limit,
+			want:   Result{Allowed: true, Limit: 10, Remaining: 0, Reset: 10 * time.Second},
+		},
+		{
+			name:   "empty bucket waits a whole token",
+			before: 10,
+			limit:  limit,
+			want:   Result{Limit: 10, Remaining: 0, Reset: 10 * time.Second, RetryAfter: time.Second},
+		},
+		{
+			name:    "partial refill waits the rest of the token",
+			before:  10,
+			advance: 250 * time.Millisecond,
+			limit:   limit,
+			want: Result{
+				Limit: 10, Remaining: 0, Reset: 9750 * time.Millisecond, RetryAfter: 750 * time.Millisecond,
+			},
+		},
+		{
+			name:    "refilled token is allowed",
+			before:  10,
+			advance: time.Second,
+			limit:   limit,
+			want:    Result{Allowed: true, Limit: 10, Remaining: 0, Reset: 10 * time.Second},
+		},
+		{
+			name:    "refill is linear",
+			before:  10,
+			advance: 4 * time.Second,
+			limit:   limit,
+			want:    Result{Allowed: true, Limit: 10, Remaining: 3, Reset: 7 * time.Second},
+		},
+		{
+			name:    "refill is capped at the limit",
+			before:  5,
+			advance: time.Hour,
+			limit:   limit,
+			want:    Result{Allowed: true, Limit: 10, Remaining: 9, Reset: time.Second},
+		},
+		{
+			name:   "disabled limit allows everything",
+			before: 100,
+			limit:  Limit{Window: time.Minute},
+			want:   Result{Allowed: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := start
+			tb := NewTokenBucket()
+			tb.now = func() time.Time { return now }
+
+			for i := range tt.before {
+				if _, err := tb.Allow(context.Background(), "k", tt.limit); err != nil {
+					t.Fatalf("request %d: %v", i, err)
+				}
+			}
+			now = now.Add(tt.advance)
+
+			got, err := tb.Allow(context.Background(), "k", tt.limit)
+			if err != nil {
+				t.Fatalf("Allow() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Allow() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenBucketKeysAreIndependent(t *testing.T) {
+	limit := Limit{Requests: 1, Window: time.Minute}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tb := NewTokenBucket()
+	tb.now = func() time.Time { return now }
+
+	for _, key := range []string{"a", "b"} {
+		got, err := tb.Allow(context.Background(), key, limit)
+		if err != nil {
+			t.Fatalf("Allow(%q) error = %v", key, err)
+		}
+		if !got.Allowed {
+			t.Errorf("Allow(%q) denied, want allowed", key)
+		}
+	}
+	if got, _ := tb.Allow(context.Background(), "a", limit); got.Allowed {
+		t.Error("Allow(a) allowed past its limit")
+	}
+}
+
+func TestTokenBucketSweepsIdleBuckets(t *testing.T) {
+	limit := Limit{Requests: 1, Window: time.Minute}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tb := NewTokenBucket()
+	tb.now = func() time.Time { return now }
+
+	_, _ = tb.Allow(context.Background(), "idle", limit)
+	now = now.Add(2 * time.Minute)
+	_, _ = tb.Allow(context.Background(), "active", limit)
+
+	if _, ok := tb.buckets["idle"]; ok {
+		t.Error("idle bucket was not swept")
+	}
+	if _, ok := tb.buckets["active"]; !ok {
+		t.Error("active bucket was swept")
+	}
+}
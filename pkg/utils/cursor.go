@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/wonjinsin/go-boilerplate/internal/constants"
+	pkgErrors "github.com/wonjinsin/go-boilerplate/pkg/errors"
+)
+
+// EncodeCursor serializes v as JSON and signs it with HMAC-SHA256.
+// The result is an opaque, URL-safe token of the form payload.signature.
+func EncodeCursor(secret []byte, v any) (string, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return "", pkgErrors.Wrap(err, "failed to encode cursor")
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	signature := base64.RawURLEncoding.EncodeToString(signCursor(secret, encoded))
+	return encoded + "." + signature, nil
+}
+
+// DecodeCursor verifies the signature of a token produced by EncodeCursor and
+// deserializes its payload into v.
+func DecodeCursor(secret []byte, token string, v any) error {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return pkgErrors.New(constants.InvalidParameter, "malformed cursor", nil)
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, signCursor(secret, encoded)) {
+		return pkgErrors.New(constants.InvalidParameter, "invalid cursor signature", nil)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return pkgErrors.New(constants.InvalidParameter, "malformed cursor", err)
+	}
+	if err := json.Unmarshal(payload, v); err != nil {
+		return pkgErrors.New(constants.InvalidParameter, "malformed cursor", err)
+	}
+	return nil
+}
+
+// signCursor computes the HMAC-SHA256 of an encoded cursor payload.
+func signCursor(secret []byte, encoded string) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(encoded))
+	return h.Sum(nil)
+}
@@ -2,13 +2,20 @@ package utils
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"mime"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 
+	internalConstants "github.com/wonjinsin/go-boilerplate/internal/constants"
 	"github.com/wonjinsin/go-boilerplate/pkg/constants"
 	pkgErrors "github.com/wonjinsin/go-boilerplate/pkg/errors"
+	"github.com/wonjinsin/go-boilerplate/pkg/logger"
 )
 
 // ParsePagination extracts pagination parameters from HTTP request.
@@ -37,15 +44,80 @@ func WriteJSON(w http.ResponseWriter, code int, v any) {
 	}
 }
 
-// ParseJSONBody parses JSON request body into the provided struct.
-func ParseJSONBody(r *http.Request, v any) error {
+// Validator is implemented by request payloads that check their own fields.
+type Validator interface {
+	Validate() error
+}
+
+// ParseJSONBody parses a JSON request body into the provided struct.
+// The body must be declared with one of mediaTypes, application/json when none are given,
+// and hold a single JSON value without unknown fields. Bodies cut off by
+// http.MaxBytesReader are reported as too large. If v implements Validator, its
+// violations are returned as well.
+func ParseJSONBody(r *http.Request, v any, mediaTypes ...string) error {
 	defer r.Body.Close()
-	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
-		return pkgErrors.Wrap(err, "failed to decode JSON body")
+
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{constants.ContentTypeJSON}
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get(constants.HeaderContentType))
+	if err != nil || !slices.Contains(mediaTypes, mediaType) {
+		return pkgErrors.New(internalConstants.UnsupportedMedia,
+			"content type must be "+strings.Join(mediaTypes, " or "), nil)
+	}
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return decodeError(err)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return decodeError(err)
+		}
+		return pkgErrors.New(internalConstants.InvalidParameter, "request body must hold a single JSON value", nil)
+	}
+
+	if validator, ok := v.(Validator); ok {
+		return validator.Validate()
 	}
 	return nil
 }
 
+// decodeError converts a JSON decoding error into a client error, naming the offending
+// field where the decoder reports one.
+func decodeError(err error) error {
+	var customErr *pkgErrors.CustomError
+	var maxBytesErr *http.MaxBytesError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &customErr):
+		// Returned by a custom UnmarshalJSON.
+		return err
+	case errors.As(err, &maxBytesErr):
+		return pkgErrors.New(internalConstants.PayloadTooLarge,
+			fmt.Sprintf("request body exceeds %d bytes", maxBytesErr.Limit), err)
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return pkgErrors.NewValidation([]pkgErrors.FieldViolation{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Message: "must be of type " + typeErr.Type.String(),
+		}})
+	}
+
+	// encoding/json reports unknown fields only through the message.
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return pkgErrors.NewValidation([]pkgErrors.FieldViolation{{
+			Field:   strings.Trim(field, `"`),
+			Rule:    "unknown",
+			Message: "unknown field",
+		}})
+	}
+	return pkgErrors.New(internalConstants.InvalidParameter, "invalid json", err)
+}
+
 // ExtractPathParam extracts path parameter from URL
 // Example: ExtractPathParam("/users/123", "/users/") returns "123".
 func ExtractPathParam(path, prefix string) string {
@@ -62,6 +134,26 @@ type StandardResponse struct {
 	Result any    `json:"result,omitempty"`
 }
 
+// ErrorResult represents the error result structure of the standard response.
+type ErrorResult struct {
+	Msg        string                     `json:"msg"`
+	Violations []pkgErrors.FieldViolation `json:"violations,omitempty"`
+}
+
+// ProblemDetails represents an RFC 7807 error response, extended with the TrID and
+// the 4-digit error code.
+type ProblemDetails struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	TrID     string `json:"trid"`
+	Code     string `json:"code"`
+
+	Violations []pkgErrors.FieldViolation `json:"violations,omitempty"`
+}
+
 // WriteStandardJSON writes a standard JSON response with TrID
 // Accepts an optional custom code string. If not provided, uses HTTP status code.
 func WriteStandardJSON(
@@ -103,3 +195,72 @@ func WriteStandardJSON(
 		log.Printf("json encode error: %v", err)
 	}
 }
+
+// WriteError logs err and writes its response, with the HTTP status and log level
+// registered for its code. Errors with unregistered codes are internal errors.
+// Only the public message of err is rendered.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	ctx := r.Context()
+
+	code := pkgErrors.GetCode(err)
+	m, ok := pkgErrors.Lookup(code)
+	if !ok {
+		code = internalConstants.InternalError
+		m, _ = pkgErrors.Lookup(code)
+	}
+
+	switch m.Level {
+	case pkgErrors.LevelWarn:
+		logger.LogWarn(ctx, "request failed: "+err.Error())
+	default:
+		logger.LogError(ctx, "request failed", err)
+	}
+
+	WriteErrorJSON(w, r, m.Status, pkgErrors.PublicMessage(err), string(code), pkgErrors.GetViolations(err)...)
+}
+
+// WriteErrorJSON writes an error response in the format negotiated for the request:
+// the standard envelope, or problem details when the ErrorFormat middleware selected them.
+// msg and violations must be safe to show to clients; when msg is empty, the HTTP status
+// text is used.
+func WriteErrorJSON(
+	w http.ResponseWriter,
+	r *http.Request,
+	httpStatus int,
+	msg string,
+	code string,
+	violations ...pkgErrors.FieldViolation,
+) {
+	if msg == "" {
+		msg = http.StatusText(httpStatus)
+	}
+
+	format, _ := r.Context().Value(constants.ContextKeyErrorFormat).(string)
+	if format != constants.ErrorFormatProblem {
+		WriteStandardJSON(w, r, httpStatus, ErrorResult{Msg: msg, Violations: violations}, code)
+		return
+	}
+
+	trID, _ := r.Context().Value(constants.ContextKeyTrID).(string)
+	if code == "" {
+		code = fmt.Sprintf("%04d", httpStatus)
+	}
+	problem := ProblemDetails{
+		Type:     constants.ProblemTypeDefault,
+		Title:    http.StatusText(httpStatus),
+		Status:   httpStatus,
+		Detail:   msg,
+		Instance: r.URL.Path,
+		TrID:     trID,
+		Code:     code,
+
+		Violations: violations,
+	}
+
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeProblemJSON)
+	w.WriteHeader(httpStatus)
+
+	if err := json.NewEncoder(w).Encode(problem); err != nil {
+		log.Printf("json encode error: %v", err)
+	}
+}
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"regexp"
 	"strings"
 	"unicode"
@@ -50,3 +52,10 @@ func NormalizeName(name string) string {
 func IsEmptyOrWhitespace(s string) bool {
 	return len(strings.TrimSpace(s)) == 0
 }
+
+// HashSHA256 returns the hex-encoded SHA-256 digest of s.
+// Suitable for looking up high-entropy secrets such as tokens, not for passwords.
+func HashSHA256(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
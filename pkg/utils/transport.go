@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"net/http"
+
+	"github.com/wonjinsin/go-boilerplate/pkg/constants"
+)
+
+// trIDTransport forwards the TrID of the request context on outbound requests.
+type trIDTransport struct {
+	base   http.RoundTripper
+	header string
+}
+
+// NewTrIDTransport wraps base so outbound requests made with a request context carry its
+// TrID in header (X-Request-ID when empty). Requests that already set the header are left as is.
+func NewTrIDTransport(base http.RoundTripper, header string) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if header == "" {
+		header = constants.HeaderRequestID
+	}
+	return &trIDTransport{base: base, header: header}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *trIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	trID, ok := req.Context().Value(constants.ContextKeyTrID).(string)
+	if !ok || trID == "" || req.Header.Get(t.header) != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the caller's request.
+	req = req.Clone(req.Context())
+	req.Header.Set(t.header, trID)
+	return t.base.RoundTrip(req)
+}